@@ -2,7 +2,9 @@ package aggregate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/get-eventually/go-eventually/event"
 	"github.com/get-eventually/go-eventually/serde"
@@ -51,12 +53,19 @@ func RehydrateFromState[I ID, Src Root[I], Dst any](
 	return src, nil
 }
 
+// ErrVersionNotFound is returned by HistoricalGetter implementations when
+// the requested Aggregate Root version does not exist.
+var ErrVersionNotFound = errors.New("aggregate: root version not found")
+
 // Factory is a function that creates new zero-valued
 // instances of an aggregate.Root implementation.
 type Factory[I ID, T Root[I]] func() T
 
 // EventSourcedRepository provides an aggregate.Repository interface implementation
 // that uses an event.Store to store and load the state of the Aggregate Root.
+//
+// EventSourcedRepository also implements aggregate.HistoricalGetter, since
+// past states of the Aggregate Root can be rehydrated from its Event Stream.
 type EventSourcedRepository[I ID, T Root[I]] struct {
 	eventStore event.Store
 	typ        Type[I, T]
@@ -96,6 +105,88 @@ func (repo EventSourcedRepository[I, T]) Get(ctx context.Context, id I) (T, erro
 	return root, nil
 }
 
+// GetAtVersion returns the Aggregate Root with the specified id, as it was
+// at the specified version, by replaying its Event Stream up to that version.
+//
+// aggregate.ErrRootNotFound is returned if no Aggregate Root was found with that id,
+// while aggregate.ErrVersionNotFound is returned if the Aggregate Root has not
+// reached the specified version yet, or if the specified version is zero.
+func (repo EventSourcedRepository[I, T]) GetAtVersion(ctx context.Context, id I, v version.Version) (T, error) {
+	var zeroValue T
+
+	// NOTE: a zero upper bound in version.Selector means no upper bound at all.
+	if v == 0 {
+		return zeroValue, fmt.Errorf("aggregate.EventSourcedRepository: invalid version 0, %w", ErrVersionNotFound)
+	}
+
+	streamID := event.StreamID(id.String())
+	stream := repo.eventStore.Stream(ctx, streamID, version.Selector{From: 0, To: v, Direction: version.Forward})
+
+	root := repo.typ.Factory()
+	if err := RehydrateFromEvents(root, stream); err != nil {
+		return zeroValue, fmt.Errorf("aggregate.EventSourcedRepository: failed to rehydrate aggregate root, %w", err)
+	}
+
+	if root.Version() == 0 {
+		return zeroValue, ErrRootNotFound
+	}
+
+	if root.Version() != v {
+		return zeroValue, fmt.Errorf(
+			"aggregate.EventSourcedRepository: failed to get version %d, latest is %d, %w",
+			v, root.Version(), ErrVersionNotFound,
+		)
+	}
+
+	return root, nil
+}
+
+// GetAtTime returns the Aggregate Root with the specified id, as it was
+// at the specified point in time, by replaying the Domain Events recorded
+// up until then.
+//
+// The time a Domain Event has been recorded is read from its Metadata
+// (see event.RecordedAtMetadataKey), which must be set by the Event Store.
+//
+// aggregate.ErrRootNotFound is returned if the Aggregate Root did not exist
+// at the specified point in time.
+func (repo EventSourcedRepository[I, T]) GetAtTime(ctx context.Context, id I, t time.Time) (T, error) {
+	var zeroValue T
+
+	streamID := event.StreamID(id.String())
+	stream := repo.eventStore.Stream(ctx, streamID, version.SelectFromBeginning)
+
+	recordedUntil := event.NewStream(func(yield func(event.Persisted) bool) error {
+		for evt := range stream.Iter() {
+			recordedAt, err := evt.RecordedAt()
+			if err != nil {
+				return fmt.Errorf("failed to read recorded-at of event version %d, %w", evt.Version, err)
+			}
+
+			if recordedAt.After(t) || !yield(evt) {
+				return nil
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			return fmt.Errorf("failed to stream events, %w", err)
+		}
+
+		return nil
+	})
+
+	root := repo.typ.Factory()
+	if err := RehydrateFromEvents(root, recordedUntil); err != nil {
+		return zeroValue, fmt.Errorf("aggregate.EventSourcedRepository: failed to rehydrate aggregate root, %w", err)
+	}
+
+	if root.Version() == 0 {
+		return zeroValue, ErrRootNotFound
+	}
+
+	return root, nil
+}
+
 // Save stores the Aggregate Root to the Event Store, by adding the
 // new, uncommitted Domain Events recorded through the Root, if any.
 //
@@ -106,6 +197,8 @@ func (repo EventSourcedRepository[I, T]) Save(ctx context.Context, root T) error
 		return nil
 	}
 
+	event.StampCause(ctx, events)
+
 	streamID := event.StreamID(root.AggregateID().String())
 	expectedVersion := version.CheckExact(root.Version() - version.Version(len(events))) //nolint:gosec // This should not overflow.
 
@@ -2,6 +2,8 @@ package aggregate_test
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"testing"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/get-eventually/go-eventually/aggregate"
 	"github.com/get-eventually/go-eventually/event"
 	"github.com/get-eventually/go-eventually/internal/user"
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/version"
 )
 
 func TestEventSourcedRepository(t *testing.T) {
@@ -41,3 +45,150 @@ func TestEventSourcedRepository(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, usr, got)
 }
+
+// userAt returns the User created at the specified time, with the specified
+// email updates applied and no recorded events left to commit.
+func userAt(t *testing.T, id uuid.UUID, now time.Time, emails ...string) *user.User {
+	t.Helper()
+
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", now, now)
+	require.NoError(t, err)
+
+	for _, email := range emails {
+		require.NoError(t, usr.UpdateEmail(email, now, nil))
+	}
+
+	usr.FlushRecordedEvents()
+
+	return usr
+}
+
+func TestEventSourcedRepository_GetAtVersion(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	userRepository := aggregate.NewEventSourcedRepository(event.NewInMemoryStore(), user.Type)
+
+	_, err := userRepository.GetAtVersion(ctx, id, 1)
+	require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", now, now)
+	require.NoError(t, err)
+	require.NoError(t, usr.UpdateEmail("john.doe@mail.com", now, nil))
+	require.NoError(t, usr.UpdateEmail("johndoe@gmail.com", now, nil))
+	require.NoError(t, userRepository.Save(ctx, usr))
+
+	got, err := userRepository.GetAtVersion(ctx, id, 2)
+	require.NoError(t, err)
+	assert.Equal(t, userAt(t, id, now, "john.doe@mail.com"), got)
+
+	got, err = userRepository.GetAtVersion(ctx, id, 3)
+	require.NoError(t, err)
+	assert.Equal(t, usr, got)
+
+	_, err = userRepository.GetAtVersion(ctx, id, 4)
+	require.ErrorIs(t, err, aggregate.ErrVersionNotFound)
+
+	// The zero version must not select the whole Event Stream.
+	_, err = userRepository.GetAtVersion(ctx, id, 0)
+	require.ErrorIs(t, err, aggregate.ErrVersionNotFound)
+}
+
+func TestEventSourcedRepository_GetAtTime(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	userRepository := aggregate.NewEventSourcedRepository(event.NewInMemoryStore(), user.Type)
+
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", now, now)
+	require.NoError(t, err)
+
+	beforeCreation := time.Now()
+	require.NoError(t, userRepository.Save(ctx, usr))
+	afterCreation := time.Now()
+
+	require.NoError(t, usr.UpdateEmail("john.doe@mail.com", now, nil))
+	require.NoError(t, userRepository.Save(ctx, usr))
+	afterUpdate := time.Now()
+
+	_, err = userRepository.GetAtTime(ctx, id, beforeCreation.Add(-time.Nanosecond))
+	require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+
+	got, err := userRepository.GetAtTime(ctx, id, afterCreation)
+	require.NoError(t, err)
+	assert.Equal(t, userAt(t, id, now), got)
+
+	got, err = userRepository.GetAtTime(ctx, id, afterUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, userAt(t, id, now, "john.doe@mail.com"), got)
+}
+
+// unrecordedStore is an event.Store that does not record the time
+// Domain Events have been appended.
+type unrecordedStore struct {
+	*event.InMemoryStore
+}
+
+func (s unrecordedStore) Stream(ctx context.Context, id event.StreamID, selector version.Selector) *event.Stream {
+	stream := s.InMemoryStore.Stream(ctx, id, selector)
+
+	return event.NewStream(func(yield func(event.Persisted) bool) error {
+		for evt := range stream.Iter() {
+			evt.Metadata = maps.Clone(evt.Metadata)
+			delete(evt.Metadata, event.RecordedAtMetadataKey)
+
+			if !yield(evt) {
+				return nil
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			return fmt.Errorf("unrecordedStore: failed to stream events, %w", err)
+		}
+
+		return nil
+	})
+}
+
+func TestEventSourcedRepository_GetAtTime_MissingRecordedAt(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	userRepository := aggregate.NewEventSourcedRepository(unrecordedStore{event.NewInMemoryStore()}, user.Type)
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", now, now)
+	require.NoError(t, err)
+	require.NoError(t, userRepository.Save(ctx, usr))
+
+	_, err = userRepository.GetAtTime(ctx, id, time.Now())
+	require.ErrorIs(t, err, event.ErrRecordedAtNotFound)
+}
+
+func TestEventSourcedRepository_StampsCause(t *testing.T) {
+	id := uuid.New()
+	cause := message.Metadata{}.WithMessageID("command").WithCorrelationID("flow")
+	ctx := message.ContextWithCause(t.Context(), cause)
+
+	eventStore := event.NewInMemoryStore()
+	userRepository := aggregate.NewEventSourcedRepository(eventStore, user.Type)
+
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, userRepository.Save(ctx, usr))
+
+	stream := eventStore.Stream(ctx, event.StreamID(id.String()), version.SelectFromBeginning)
+
+	var events []event.Persisted
+	for evt := range stream.Iter() {
+		events = append(events, evt)
+	}
+
+	require.NoError(t, stream.Err())
+	require.Len(t, events, 1)
+
+	assert.NotEmpty(t, events[0].Metadata.MessageID())
+	assert.Equal(t, "flow", events[0].Metadata.CorrelationID())
+	assert.Equal(t, "command", events[0].Metadata.CausationID())
+}
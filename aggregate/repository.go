@@ -3,6 +3,9 @@ package aggregate
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/get-eventually/go-eventually/version"
 )
 
 // ErrRootNotFound is returned when the Aggregate Root requested
@@ -21,6 +24,18 @@ type Saver[I ID, T Root[I]] interface {
 	Save(ctx context.Context, root T) error
 }
 
+// HistoricalGetter is an Aggregate Repository interface component,
+// that can be used for retrieving Aggregate Roots as they were in the past,
+// e.g. for auditing purposes.
+type HistoricalGetter[I ID, T Root[I]] interface {
+	// GetAtVersion returns the Aggregate Root as it was at the specified version.
+	GetAtVersion(ctx context.Context, id I, v version.Version) (T, error)
+
+	// GetAtTime returns the Aggregate Root as it was at the specified point in time,
+	// including all the Domain Events recorded up until then.
+	GetAtTime(ctx context.Context, id I, t time.Time) (T, error)
+}
+
 // Repository is an interface used to get Aggregate Roots from and save them to
 // some kind of storage, depending on the implementation.
 type Repository[I ID, T Root[I]] interface {
@@ -0,0 +1,128 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+// ErrSnapshotNotFound is returned by a SnapshotStore when no Snapshot
+// has been taken yet for the requested Aggregate Root.
+var ErrSnapshotNotFound = errors.New("aggregate: snapshot not found")
+
+// Snapshot is the serialized state of an Aggregate Root, taken
+// at a specific version of its Event Stream.
+type Snapshot struct {
+	Version    version.Version
+	State      []byte
+	RecordedAt time.Time
+}
+
+// SnapshotStore is used to store and retrieve the latest Snapshot
+// of an Aggregate Root, addressed by its Event Stream id.
+type SnapshotStore interface {
+	// LatestSnapshot returns the latest Snapshot taken for the specified Event Stream.
+	//
+	// ErrSnapshotNotFound is returned if no Snapshot has been taken yet.
+	LatestSnapshot(ctx context.Context, id event.StreamID) (Snapshot, error)
+
+	// SaveSnapshot stores a new Snapshot for the specified Event Stream.
+	//
+	// Snapshots older than the latest one stored should be discarded.
+	SaveSnapshot(ctx context.Context, id event.StreamID, snapshot Snapshot) error
+}
+
+// Interface implementation assertion.
+var _ SnapshotStore = new(InMemorySnapshotStore)
+
+// InMemorySnapshotStore is a thread-safe, in-memory SnapshotStore implementation.
+type InMemorySnapshotStore struct {
+	mx        sync.RWMutex
+	snapshots map[event.StreamID]Snapshot
+}
+
+// NewInMemorySnapshotStore creates a new aggregate.InMemorySnapshotStore instance.
+func NewInMemorySnapshotStore() *InMemorySnapshotStore {
+	return &InMemorySnapshotStore{
+		mx:        sync.RWMutex{},
+		snapshots: make(map[event.StreamID]Snapshot),
+	}
+}
+
+// LatestSnapshot implements the aggregate.SnapshotStore interface.
+func (s *InMemorySnapshotStore) LatestSnapshot(_ context.Context, id event.StreamID) (Snapshot, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	snapshot, ok := s.snapshots[id]
+	if !ok {
+		return Snapshot{}, ErrSnapshotNotFound
+	}
+
+	return snapshot, nil
+}
+
+// SaveSnapshot implements the aggregate.SnapshotStore interface.
+func (s *InMemorySnapshotStore) SaveSnapshot(_ context.Context, id event.StreamID, snapshot Snapshot) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if latest, ok := s.snapshots[id]; ok && latest.Version >= snapshot.Version {
+		return nil
+	}
+
+	s.snapshots[id] = snapshot
+
+	return nil
+}
+
+// SnapshotPolicy decides whether a new Snapshot should be taken
+// for an Aggregate Root that has been saved at the current version.
+//
+// The latest Snapshot taken is provided in input, or a zero value
+// if no Snapshot has been taken yet.
+type SnapshotPolicy interface {
+	ShouldSnapshot(latest Snapshot, current version.Version, now time.Time) bool
+}
+
+// SnapshotPolicyFunc is a functional implementation of the SnapshotPolicy interface.
+type SnapshotPolicyFunc func(latest Snapshot, current version.Version, now time.Time) bool
+
+// ShouldSnapshot implements the aggregate.SnapshotPolicy interface.
+func (fn SnapshotPolicyFunc) ShouldSnapshot(latest Snapshot, current version.Version, now time.Time) bool {
+	return fn(latest, current, now)
+}
+
+// SnapshotEveryNEvents returns a SnapshotPolicy that takes a new Snapshot
+// once at least n Domain Events have been recorded since the latest Snapshot.
+func SnapshotEveryNEvents(n uint32) SnapshotPolicy {
+	return SnapshotPolicyFunc(func(latest Snapshot, current version.Version, _ time.Time) bool {
+		return current >= latest.Version+version.Version(n)
+	})
+}
+
+// SnapshotEveryInterval returns a SnapshotPolicy that takes a new Snapshot
+// once at least the specified interval has passed since the latest Snapshot.
+func SnapshotEveryInterval(interval time.Duration) SnapshotPolicy {
+	return SnapshotPolicyFunc(func(latest Snapshot, _ version.Version, now time.Time) bool {
+		return now.Sub(latest.RecordedAt) >= interval
+	})
+}
+
+// SnapshotWhenAny returns a SnapshotPolicy that takes a new Snapshot
+// when any of the provided policies decide to.
+func SnapshotWhenAny(policies ...SnapshotPolicy) SnapshotPolicy {
+	return SnapshotPolicyFunc(func(latest Snapshot, current version.Version, now time.Time) bool {
+		for _, policy := range policies {
+			if policy.ShouldSnapshot(latest, current, now) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
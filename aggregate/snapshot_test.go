@@ -0,0 +1,50 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestInMemorySnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	store := aggregate.NewInMemorySnapshotStore()
+
+	_, err := store.LatestSnapshot(ctx, "stream")
+	require.ErrorIs(t, err, aggregate.ErrSnapshotNotFound)
+
+	snapshot := aggregate.Snapshot{Version: 5, State: []byte("state"), RecordedAt: time.Now()}
+	require.NoError(t, store.SaveSnapshot(ctx, "stream", snapshot))
+
+	// Older snapshots are discarded.
+	require.NoError(t, store.SaveSnapshot(ctx, "stream", aggregate.Snapshot{Version: 3, State: []byte("old")}))
+
+	got, err := store.LatestSnapshot(ctx, "stream")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, got)
+}
+
+func TestSnapshotPolicies(t *testing.T) {
+	now := time.Now()
+	latest := aggregate.Snapshot{Version: 10, RecordedAt: now.Add(-time.Minute)}
+
+	everyFive := aggregate.SnapshotEveryNEvents(5)
+	assert.False(t, everyFive.ShouldSnapshot(latest, 14, now))
+	assert.True(t, everyFive.ShouldSnapshot(latest, 15, now))
+	assert.True(t, everyFive.ShouldSnapshot(aggregate.Snapshot{}, 5, now))
+
+	everyHour := aggregate.SnapshotEveryInterval(time.Hour)
+	assert.False(t, everyHour.ShouldSnapshot(latest, 11, now))
+	assert.True(t, everyHour.ShouldSnapshot(latest, 11, now.Add(time.Hour)))
+
+	whenAny := aggregate.SnapshotWhenAny(everyFive, everyHour)
+	assert.False(t, whenAny.ShouldSnapshot(latest, version.Version(11), now))
+	assert.True(t, whenAny.ShouldSnapshot(latest, version.Version(15), now))
+	assert.True(t, whenAny.ShouldSnapshot(latest, version.Version(11), now.Add(time.Hour)))
+}
@@ -0,0 +1,144 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+// SnapshottingRepository provides an aggregate.Repository interface implementation
+// that uses an event.Store to store and load the state of the Aggregate Root,
+// and a SnapshotStore to avoid replaying the full Event Stream on every load.
+//
+// On Get, the latest Snapshot is deserialized through the provided serde, and only
+// the Domain Events recorded after the Snapshot version are replayed on top of it.
+//
+// On Save, a new Snapshot is taken if the configured SnapshotPolicy decides so.
+type SnapshottingRepository[I ID, T Root[I]] struct {
+	eventStore    event.Store
+	snapshotStore SnapshotStore
+	typ           Type[I, T]
+	serde         serde.Bytes[T]
+	policy        SnapshotPolicy
+}
+
+// NewSnapshottingRepository returns a new SnapshottingRepository implementation
+// to store and load Aggregate Roots, specified by the aggregate.Type,
+// using the provided event.Store and SnapshotStore implementations.
+func NewSnapshottingRepository[I ID, T Root[I]](
+	eventStore event.Store,
+	snapshotStore SnapshotStore,
+	typ Type[I, T],
+	rootSerde serde.Bytes[T],
+	policy SnapshotPolicy,
+) SnapshottingRepository[I, T] {
+	return SnapshottingRepository[I, T]{
+		eventStore:    eventStore,
+		snapshotStore: snapshotStore,
+		typ:           typ,
+		serde:         rootSerde,
+		policy:        policy,
+	}
+}
+
+// Get returns the Aggregate Root with the specified id.
+//
+// aggregate.ErrRootNotFound is returned if no Aggregate Root was found with that id.
+//
+// An error is returned if the underlying Event Store or Snapshot Store fail,
+// or if an error occurs while trying to rehydrate the Aggregate Root state
+// from its latest Snapshot and Event Stream.
+func (repo SnapshottingRepository[I, T]) Get(ctx context.Context, id I) (T, error) {
+	var zeroValue T
+
+	streamID := event.StreamID(id.String())
+	selector := version.SelectFromBeginning
+	root := repo.typ.Factory()
+
+	snapshot, err := repo.snapshotStore.LatestSnapshot(ctx, streamID)
+
+	switch {
+	case errors.Is(err, ErrSnapshotNotFound):
+	case err != nil:
+		return zeroValue, fmt.Errorf("aggregate.SnapshottingRepository: failed to get latest snapshot, %w", err)
+	default:
+		if root, err = RehydrateFromState[I](snapshot.Version, snapshot.State, repo.serde); err != nil {
+			return zeroValue, fmt.Errorf("aggregate.SnapshottingRepository: failed to rehydrate aggregate root from snapshot, %w", err)
+		}
+
+		selector = version.Selector{From: snapshot.Version + 1, To: 0, Direction: version.Forward}
+	}
+
+	stream := repo.eventStore.Stream(ctx, streamID, selector)
+	if err := RehydrateFromEvents(root, stream); err != nil {
+		return zeroValue, fmt.Errorf("aggregate.SnapshottingRepository: failed to rehydrate aggregate root, %w", err)
+	}
+
+	if root.Version() == 0 {
+		return zeroValue, ErrRootNotFound
+	}
+
+	return root, nil
+}
+
+// Save stores the Aggregate Root to the Event Store, by adding the
+// new, uncommitted Domain Events recorded through the Root, if any.
+//
+// After the Domain Events have been committed, a new Snapshot is taken
+// if the SnapshotPolicy decides so. Since Snapshots are only an optimization,
+// failing to take a Snapshot does not fail Save: the Aggregate Root will be
+// rehydrated from the previous Snapshot and the Event Stream instead.
+// Wrap the SnapshotStore if you need to observe such failures.
+//
+// An error is returned if the underlying Event Store fails.
+func (repo SnapshottingRepository[I, T]) Save(ctx context.Context, root T) error {
+	events := root.FlushRecordedEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	event.StampCause(ctx, events)
+
+	streamID := event.StreamID(root.AggregateID().String())
+	expectedVersion := version.CheckExact(root.Version() - version.Version(len(events))) //nolint:gosec // This should not overflow.
+
+	if _, err := repo.eventStore.Append(ctx, streamID, expectedVersion, events...); err != nil {
+		return fmt.Errorf("aggregate.SnapshottingRepository: failed to commit recorded events, %w", err)
+	}
+
+	repo.snapshot(ctx, streamID, root)
+
+	return nil
+}
+
+// snapshot takes a new Snapshot of the Aggregate Root, if the SnapshotPolicy
+// decides so. Failures are not reported, as Snapshots are best-effort.
+func (repo SnapshottingRepository[I, T]) snapshot(ctx context.Context, id event.StreamID, root T) {
+	latest, err := repo.snapshotStore.LatestSnapshot(ctx, id)
+	if err != nil && !errors.Is(err, ErrSnapshotNotFound) {
+		return
+	}
+
+	now := time.Now()
+
+	if latest.Version >= root.Version() || !repo.policy.ShouldSnapshot(latest, root.Version(), now) {
+		return
+	}
+
+	state, err := repo.serde.Serialize(root)
+	if err != nil {
+		return
+	}
+
+	//nolint:errcheck // Snapshots are best-effort, see Save.
+	repo.snapshotStore.SaveSnapshot(ctx, id, Snapshot{
+		Version:    root.Version(),
+		State:      state,
+		RecordedAt: now,
+	})
+}
@@ -0,0 +1,87 @@
+package aggregate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
+	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+var userSerde = serde.Chain(
+	user.ProtoSerde,
+	serde.NewProto(func() *userv1.User { return new(userv1.User) }),
+)
+
+type selectorRecordingStore struct {
+	event.Store
+
+	selectors []version.Selector
+}
+
+func (s *selectorRecordingStore) Stream(ctx context.Context, id event.StreamID, selector version.Selector) *event.Stream {
+	s.selectors = append(s.selectors, selector)
+
+	return s.Store.Stream(ctx, id, selector)
+}
+
+func TestSnapshottingRepository(t *testing.T) {
+	user.AggregateRepositorySuite(aggregate.NewSnapshottingRepository(
+		event.NewInMemoryStore(),
+		aggregate.NewInMemorySnapshotStore(),
+		user.Type,
+		userSerde,
+		aggregate.SnapshotEveryNEvents(2),
+	))(t)
+}
+
+func TestSnapshottingRepository_ReplaysOnlyEventsAfterSnapshot(t *testing.T) {
+	var (
+		id        = uuid.New()
+		birthDate = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+		now       = time.Now()
+	)
+
+	ctx := context.Background()
+	eventStore := &selectorRecordingStore{Store: event.NewInMemoryStore()}
+	snapshotStore := aggregate.NewInMemorySnapshotStore()
+
+	repository := aggregate.NewSnapshottingRepository(
+		eventStore, snapshotStore, user.Type, userSerde,
+		aggregate.SnapshotEveryNEvents(2),
+	)
+
+	usr, err := user.Create(id, "John", "Doe", "john@doe.com", birthDate, now)
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(ctx, usr))
+
+	_, err = snapshotStore.LatestSnapshot(ctx, event.StreamID(id.String()))
+	require.ErrorIs(t, err, aggregate.ErrSnapshotNotFound, "no snapshot expected before 2 events")
+
+	require.NoError(t, usr.UpdateEmail("john.doe@mail.com", now, nil))
+	require.NoError(t, repository.Save(ctx, usr))
+
+	snapshot, err := snapshotStore.LatestSnapshot(ctx, event.StreamID(id.String()))
+	require.NoError(t, err)
+	assert.Equal(t, version.Version(2), snapshot.Version)
+
+	require.NoError(t, usr.UpdateEmail("johndoe@gmail.com", now, nil))
+	require.NoError(t, repository.Save(ctx, usr))
+
+	got, err := repository.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, usr, got)
+	assert.Equal(t, version.Version(3), got.Version())
+
+	require.NotEmpty(t, eventStore.selectors)
+	assert.Equal(t, version.Selector{From: 3}, eventStore.selectors[len(eventStore.selectors)-1])
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrHandlerNotRegistered is returned by Bus.Dispatch when no Handler
+	// has been registered for the type of the dispatched Command.
+	ErrHandlerNotRegistered = errors.New("command.Bus: no handler registered for command")
+
+	// ErrHandlerAlreadyRegistered is returned by Register when a Handler
+	// has already been registered for the same Command type.
+	ErrHandlerAlreadyRegistered = errors.New("command.Bus: handler already registered for command")
+)
+
+// Dispatcher is a component that dispatches Commands received as GenericEnvelope,
+// e.g. to their Handler, like Bus does.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, cmd GenericEnvelope) error
+}
+
+// DispatcherFunc is a functional type that implements the Dispatcher interface.
+type DispatcherFunc func(ctx context.Context, cmd GenericEnvelope) error
+
+// Dispatch implements the command.Dispatcher interface.
+func (fn DispatcherFunc) Dispatch(ctx context.Context, cmd GenericEnvelope) error {
+	return fn(ctx, cmd)
+}
+
+// Interface implementation assertion.
+var _ Dispatcher = new(Bus)
+
+// Bus routes Commands received as GenericEnvelope to the Handler
+// registered for their specific type.
+//
+// Useful in transport layers, where Commands are decoded into
+// a GenericEnvelope before being handled.
+//
+// Handlers are registered using Register, and routed by the Go type
+// of the Command: make sure to dispatch Commands using the same type
+// (e.g. value or pointer) used during registration.
+//
+// Bus is safe for concurrent use.
+type Bus struct {
+	mx       sync.RWMutex
+	handlers map[reflect.Type]genericHandler
+}
+
+type genericHandler func(ctx context.Context, cmd GenericEnvelope) error
+
+// NewBus returns a new, empty Bus instance.
+func NewBus() *Bus {
+	return &Bus{
+		mx:       sync.RWMutex{},
+		handlers: make(map[reflect.Type]genericHandler),
+	}
+}
+
+// Register registers the Handler for Commands of type T in the provided Bus.
+//
+// An error wrapping ErrHandlerAlreadyRegistered is returned if a Handler
+// for the same Command type has already been registered.
+func Register[T Command](bus *Bus, handler Handler[T]) error {
+	typ := reflect.TypeFor[T]()
+
+	bus.mx.Lock()
+	defer bus.mx.Unlock()
+
+	if _, ok := bus.handlers[typ]; ok {
+		return fmt.Errorf("command.Register: failed to register handler for %s, %w", typ, ErrHandlerAlreadyRegistered)
+	}
+
+	bus.handlers[typ] = func(ctx context.Context, cmd GenericEnvelope) error {
+		envelope, ok := FromGenericEnvelope[T](cmd)
+		if !ok {
+			return fmt.Errorf("command.Bus: unexpected command type %T, expected %s", cmd.Message, typ)
+		}
+
+		return handler.Handle(ctx, envelope)
+	}
+
+	return nil
+}
+
+// Dispatch routes the provided Command to the Handler registered
+// for its type, returning the Handler result.
+//
+// An error wrapping ErrHandlerNotRegistered is returned if no Handler
+// has been registered for the Command type.
+func (bus *Bus) Dispatch(ctx context.Context, cmd GenericEnvelope) error {
+	if cmd.Message == nil {
+		return fmt.Errorf("command.Bus: failed to dispatch nil command, %w", ErrHandlerNotRegistered)
+	}
+
+	bus.mx.RLock()
+	handler, ok := bus.handlers[reflect.TypeOf(cmd.Message)]
+	bus.mx.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("command.Bus: failed to dispatch %q (%T), %w", cmd.Message.Name(), cmd.Message, ErrHandlerNotRegistered)
+	}
+
+	return handler(ctx, cmd)
+}
@@ -0,0 +1,67 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/message"
+)
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+
+	var handled []command.Envelope[commandTest1]
+
+	bus := command.NewBus()
+
+	require.NoError(t, command.Register(bus, command.HandlerFunc[commandTest1](
+		func(_ context.Context, cmd command.Envelope[commandTest1]) error {
+			handled = append(handled, cmd)
+
+			return nil
+		},
+	)))
+
+	errHandler := errors.New("handler failed")
+
+	require.NoError(t, command.Register(bus, command.HandlerFunc[commandTest2](
+		func(context.Context, command.Envelope[commandTest2]) error {
+			return errHandler
+		},
+	)))
+
+	t.Run("commands are routed to the handler registered for their type", func(t *testing.T) {
+		cmd := command.Envelope[commandTest1]{
+			Message:  commandTest1{},
+			Metadata: message.Metadata{"Test-Key": "test-value"},
+		}
+
+		require.NoError(t, bus.Dispatch(ctx, cmd.ToGenericEnvelope()))
+		assert.Equal(t, []command.Envelope[commandTest1]{cmd}, handled)
+	})
+
+	t.Run("handler errors are returned to the caller", func(t *testing.T) {
+		err := bus.Dispatch(ctx, command.ToEnvelope(commandTest2{}).ToGenericEnvelope())
+		assert.ErrorIs(t, err, errHandler)
+	})
+
+	t.Run("commands with no registered handler return an error", func(t *testing.T) {
+		err := bus.Dispatch(ctx, command.ToEnvelope(&commandTest1{}).ToGenericEnvelope())
+		assert.ErrorIs(t, err, command.ErrHandlerNotRegistered)
+
+		err = bus.Dispatch(ctx, command.GenericEnvelope{Message: nil, Metadata: nil})
+		assert.ErrorIs(t, err, command.ErrHandlerNotRegistered)
+	})
+
+	t.Run("registering a handler for the same command type twice returns an error", func(t *testing.T) {
+		err := command.Register(bus, command.HandlerFunc[commandTest1](
+			func(context.Context, command.Envelope[commandTest1]) error { return nil },
+		))
+		assert.ErrorIs(t, err, command.ErrHandlerAlreadyRegistered)
+	})
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+// DefaultDeduplicationTTL is the default time the Outcome of a Command
+// is kept by Deduplicate, to detect its duplicates.
+const DefaultDeduplicationTTL = 24 * time.Hour
+
+// DeduplicationNotifyFunc is called by Deduplicate when the Outcome
+// of a handled Command cannot be recorded.
+//
+// The Command has been handled already: its duplicates are going to be handled again.
+type DeduplicationNotifyFunc func(ctx context.Context, cmd GenericEnvelope, err error)
+
+// DeduplicationOption can be used to change the configuration of Deduplicate.
+type DeduplicationOption interface {
+	apply(*deduplicationConfig)
+}
+
+type deduplicationOption func(*deduplicationConfig)
+
+func (apply deduplicationOption) apply(cfg *deduplicationConfig) { apply(cfg) }
+
+// WithDeduplicationTTL specifies how long the Outcome of a Command is kept
+// to detect its duplicates.
+func WithDeduplicationTTL(ttl time.Duration) DeduplicationOption {
+	return deduplicationOption(func(cfg *deduplicationConfig) {
+		cfg.ttl = ttl
+	})
+}
+
+// WithDeduplicationKey specifies the Metadata key holding the ID of the Command,
+// instead of message.MessageIDMetadataKey.
+func WithDeduplicationKey(key string) DeduplicationOption {
+	return deduplicationOption(func(cfg *deduplicationConfig) {
+		cfg.key = key
+	})
+}
+
+// WithDeduplicationErrorCodes specifies the errors, keyed by a stable code,
+// that duplicates of failed Commands can be matched against using errors.Is.
+//
+// When the error returned by a Command matches one of the specified errors,
+// its code is recorded in the Outcome, and the error returned to the duplicates
+// of the Command wraps the specified error, together with ErrPreviouslyFailed.
+func WithDeduplicationErrorCodes(codes map[string]error) DeduplicationOption {
+	return deduplicationOption(func(cfg *deduplicationConfig) {
+		cfg.codes = codes
+	})
+}
+
+// WithDeduplicationNotify specifies a function to call every time the Outcome
+// of a handled Command cannot be recorded.
+//
+// Useful to log or expose these failures, which are not returned by Deduplicate.
+func WithDeduplicationNotify(notify DeduplicationNotifyFunc) DeduplicationOption {
+	return deduplicationOption(func(cfg *deduplicationConfig) {
+		cfg.notify = notify
+	})
+}
+
+type deduplicationConfig struct {
+	ttl    time.Duration
+	key    string
+	codes  map[string]error
+	notify DeduplicationNotifyFunc
+}
+
+// outcome returns the Outcome of a Command handled with the specified error,
+// recording the code of the first matching error, in code order.
+func (cfg deduplicationConfig) outcome(err error) Outcome {
+	outcome := NewOutcome(err)
+
+	if err != nil {
+		for _, code := range slices.Sorted(maps.Keys(cfg.codes)) {
+			if errors.Is(err, cfg.codes[code]) {
+				outcome.Code = code
+
+				break
+			}
+		}
+	}
+
+	return outcome
+}
+
+// err returns the error of the recorded Outcome, wrapping the error
+// specified for its code, if any.
+func (cfg deduplicationConfig) err(outcome Outcome) error {
+	err := outcome.Err()
+
+	if target, ok := cfg.codes[outcome.Code]; ok && err != nil {
+		return fmt.Errorf("%w, %w", err, target)
+	}
+
+	return err
+}
+
+// isTransient returns true if the error is not caused by the Command itself,
+// which should then be handled again if retried.
+func isTransient(err error) bool {
+	var conflictErr version.ConflictError
+
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &conflictErr)
+}
+
+// Deduplicate returns a Middleware that handles a Command only once, using
+// the ID held in its Metadata (see message.Metadata.MessageID).
+//
+// The Outcome of the Command is recorded in the provided DeduplicationStore:
+// duplicates of the Command received before the Outcome expires are not handled,
+// returning the recorded Outcome instead. Previous failures are returned
+// as an error wrapping ErrPreviouslyFailed, and the error specified
+// for their code with WithDeduplicationErrorCodes, if any.
+//
+// Errors caused by the context or by a version.ConflictError are considered
+// transient and are not recorded, so that the Command can be retried.
+// Make sure Deduplicate runs before RetryOnConflict in the Middleware chain.
+//
+// Make sure Deduplicate also runs before PropagateCause, which generates an ID
+// for the Commands that have none: otherwise, their Outcome would be recorded
+// under a random ID, never matched by their duplicates.
+//
+// Commands with no ID are always handled.
+//
+// Failures to record the Outcome do not change the result of the Command,
+// which has been handled already, and are reported with WithDeduplicationNotify.
+// To record the Outcome atomically with the changes performed by the Command,
+// handle it within a transaction the DeduplicationStore takes part in,
+// e.g. postgres.DeduplicationStore within a postgres.UnitOfWork: the Outcome
+// of failed Commands is then rolled back too, so their duplicates are handled again.
+//
+// Concurrent duplicates of a Command that has not been handled yet
+// are not detected: rely on optimistic concurrency (e.g. aggregate.Repository)
+// to reject them.
+func Deduplicate(store DeduplicationStore, options ...DeduplicationOption) Middleware {
+	cfg := deduplicationConfig{
+		ttl:    DefaultDeduplicationTTL,
+		key:    message.MessageIDMetadataKey,
+		codes:  nil,
+		notify: nil,
+	}
+
+	for _, opt := range options {
+		opt.apply(&cfg)
+	}
+
+	return func(next GenericHandlerFunc) GenericHandlerFunc {
+		return func(ctx context.Context, cmd GenericEnvelope) error {
+			id := cmd.Metadata[cfg.key]
+			if id == "" {
+				return next(ctx, cmd)
+			}
+
+			outcome, err := store.Outcome(ctx, id)
+			if err == nil {
+				return cfg.err(outcome)
+			} else if !errors.Is(err, ErrOutcomeNotFound) {
+				return fmt.Errorf("command.Deduplicate: failed to get outcome of command %q, %w", id, err)
+			}
+
+			handleErr := next(ctx, cmd)
+			if isTransient(handleErr) {
+				return handleErr
+			}
+
+			if err := store.RecordOutcome(ctx, id, cfg.outcome(handleErr), cfg.ttl); err != nil && cfg.notify != nil {
+				cfg.notify(ctx, cmd, fmt.Errorf("command.Deduplicate: failed to record outcome of command %q, %w", id, err))
+			}
+
+			return handleErr
+		}
+	}
+}
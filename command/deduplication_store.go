@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrOutcomeNotFound is returned by a DeduplicationStore when no Outcome
+	// has been recorded for a Command, or the recorded Outcome has expired.
+	ErrOutcomeNotFound = errors.New("command.DeduplicationStore: outcome not found")
+
+	// ErrPreviouslyFailed is wrapped by the error returned by Outcome.Err
+	// for Commands whose handling has failed.
+	ErrPreviouslyFailed = errors.New("command.Deduplicate: command previously failed")
+)
+
+// Outcome is the outcome of a handled Command, as recorded by a DeduplicationStore.
+type Outcome struct {
+	// Error is the message of the error returned when handling the Command,
+	// or empty if the Command has been handled successfully.
+	Error string
+	// Code identifies the kind of error returned when handling the Command,
+	// as specified with WithDeduplicationErrorCodes, or empty if unknown.
+	Code string
+}
+
+// NewOutcome returns the Outcome of a Command handled with the specified error.
+func NewOutcome(err error) Outcome {
+	if err == nil {
+		return Outcome{Error: "", Code: ""}
+	}
+
+	return Outcome{Error: err.Error(), Code: ""}
+}
+
+// Err returns the error the Command has been handled with,
+// wrapping ErrPreviouslyFailed, or nil if it has been handled successfully.
+//
+// Since only the error message is recorded, the returned error does not wrap
+// the original one: see WithDeduplicationErrorCodes to preserve its kind.
+func (o Outcome) Err() error {
+	if o.Error == "" {
+		return nil
+	}
+
+	return fmt.Errorf("command.Outcome: %s, %w", o.Error, ErrPreviouslyFailed)
+}
+
+// DeduplicationStore records the Outcome of handled Commands, addressed
+// by their ID, for a limited amount of time.
+//
+// It's used by the Deduplicate middleware to detect duplicate Commands.
+type DeduplicationStore interface {
+	// Outcome returns the Outcome recorded for the Command with the specified ID.
+	//
+	// ErrOutcomeNotFound is returned if no Outcome has been recorded,
+	// or if the recorded Outcome has expired.
+	Outcome(ctx context.Context, commandID string) (Outcome, error)
+
+	// RecordOutcome records the Outcome of the Command with the specified ID,
+	// which expires after the specified TTL.
+	RecordOutcome(ctx context.Context, commandID string, outcome Outcome, ttl time.Duration) error
+}
+
+// Interface implementation assertion.
+var _ DeduplicationStore = new(InMemoryDeduplicationStore)
+
+type inMemoryOutcome struct {
+	outcome   Outcome
+	expiresAt time.Time
+}
+
+// InMemoryDeduplicationStore is a thread-safe, in-memory DeduplicationStore implementation.
+//
+// Expired Outcomes are removed when accessed.
+type InMemoryDeduplicationStore struct {
+	mx       sync.RWMutex
+	outcomes map[string]inMemoryOutcome
+}
+
+// NewInMemoryDeduplicationStore creates a new command.InMemoryDeduplicationStore instance.
+func NewInMemoryDeduplicationStore() *InMemoryDeduplicationStore {
+	return &InMemoryDeduplicationStore{
+		mx:       sync.RWMutex{},
+		outcomes: make(map[string]inMemoryOutcome),
+	}
+}
+
+// Outcome implements the command.DeduplicationStore interface.
+func (s *InMemoryDeduplicationStore) Outcome(_ context.Context, commandID string) (Outcome, error) {
+	s.mx.RLock()
+	recorded, ok := s.outcomes[commandID]
+	s.mx.RUnlock()
+
+	if !ok {
+		return Outcome{}, ErrOutcomeNotFound //nolint:exhaustruct // This is a zero value anyway.
+	}
+
+	if !time.Now().Before(recorded.expiresAt) {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+
+		// NOTE: the Outcome might have been recorded again in the meantime.
+		if current, ok := s.outcomes[commandID]; ok && !time.Now().Before(current.expiresAt) {
+			delete(s.outcomes, commandID)
+		}
+
+		return Outcome{}, ErrOutcomeNotFound //nolint:exhaustruct // This is a zero value anyway.
+	}
+
+	return recorded.outcome, nil
+}
+
+// RecordOutcome implements the command.DeduplicationStore interface.
+func (s *InMemoryDeduplicationStore) RecordOutcome(
+	_ context.Context,
+	commandID string,
+	outcome Outcome,
+	ttl time.Duration,
+) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.outcomes[commandID] = inMemoryOutcome{
+		outcome:   outcome,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	return nil
+}
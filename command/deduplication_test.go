@@ -0,0 +1,165 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+var errHandlerFailed = errors.New("handler failed")
+
+func countingHandler(attempts *int, err error) command.Handler[commandTest1] {
+	return command.HandlerFunc[commandTest1](func(context.Context, command.Envelope[commandTest1]) error {
+		*attempts++
+
+		return err
+	})
+}
+
+func commandWithID(id string) command.Envelope[commandTest1] {
+	return command.Envelope[commandTest1]{
+		Message:  commandTest1{},
+		Metadata: message.Metadata{}.WithMessageID(id),
+	}
+}
+
+var errRecordFailed = errors.New("record failed")
+
+// failingDeduplicationStore is a command.DeduplicationStore that fails
+// to record any Outcome.
+type failingDeduplicationStore struct {
+	command.DeduplicationStore
+}
+
+func (failingDeduplicationStore) RecordOutcome(context.Context, string, command.Outcome, time.Duration) error {
+	return errRecordFailed
+}
+
+func TestDeduplicate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("duplicate commands are handled only once", func(t *testing.T) {
+		var attempts int
+
+		handler := command.Decorate(countingHandler(&attempts, nil),
+			command.Deduplicate(command.NewInMemoryDeduplicationStore()))
+
+		require.NoError(t, handler.Handle(ctx, commandWithID("first")))
+		require.NoError(t, handler.Handle(ctx, commandWithID("first")))
+		assert.Equal(t, 1, attempts)
+
+		require.NoError(t, handler.Handle(ctx, commandWithID("second")))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("duplicates of failed commands return the previous failure", func(t *testing.T) {
+		var attempts int
+
+		handler := command.Decorate(countingHandler(&attempts, errHandlerFailed),
+			command.Deduplicate(command.NewInMemoryDeduplicationStore()))
+
+		require.ErrorIs(t, handler.Handle(ctx, commandWithID("failing")), errHandlerFailed)
+
+		err := handler.Handle(ctx, commandWithID("failing"))
+		require.ErrorIs(t, err, command.ErrPreviouslyFailed)
+		require.NotErrorIs(t, err, errHandlerFailed)
+		assert.Contains(t, err.Error(), errHandlerFailed.Error())
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("duplicates of failed commands wrap the error specified for its code", func(t *testing.T) {
+		var attempts int
+
+		store := command.NewInMemoryDeduplicationStore()
+		handler := command.Decorate(countingHandler(&attempts, fmt.Errorf("failed to handle, %w", errHandlerFailed)),
+			command.Deduplicate(store, command.WithDeduplicationErrorCodes(map[string]error{
+				"handler-failed": errHandlerFailed,
+			})))
+
+		require.ErrorIs(t, handler.Handle(ctx, commandWithID("coded")), errHandlerFailed)
+
+		outcome, err := store.Outcome(ctx, "coded")
+		require.NoError(t, err)
+		assert.Equal(t, "handler-failed", outcome.Code)
+
+		err = handler.Handle(ctx, commandWithID("coded"))
+		require.ErrorIs(t, err, command.ErrPreviouslyFailed)
+		require.ErrorIs(t, err, errHandlerFailed)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("failures to record the outcome are notified without failing the command", func(t *testing.T) {
+		var (
+			attempts int
+			notified []error
+		)
+
+		store := failingDeduplicationStore{DeduplicationStore: command.NewInMemoryDeduplicationStore()}
+		handler := command.Decorate(countingHandler(&attempts, nil),
+			command.Deduplicate(store, command.WithDeduplicationNotify(
+				func(_ context.Context, _ command.GenericEnvelope, err error) {
+					notified = append(notified, err)
+				},
+			)))
+
+		require.NoError(t, handler.Handle(ctx, commandWithID("unrecorded")))
+		assert.Equal(t, 1, attempts)
+		require.Len(t, notified, 1)
+		require.ErrorIs(t, notified[0], errRecordFailed)
+
+		require.NoError(t, handler.Handle(ctx, commandWithID("unrecorded")))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("transient failures are not recorded", func(t *testing.T) {
+		var attempts int
+
+		conflict := fmt.Errorf("failed to save, %w", version.ConflictError{Expected: 1, Actual: 2})
+		handler := command.Decorate(countingHandler(&attempts, conflict),
+			command.Deduplicate(command.NewInMemoryDeduplicationStore()))
+
+		require.ErrorAs(t, handler.Handle(ctx, commandWithID("conflict")), new(version.ConflictError))
+		require.ErrorAs(t, handler.Handle(ctx, commandWithID("conflict")), new(version.ConflictError))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("commands without id are always handled", func(t *testing.T) {
+		var attempts int
+
+		handler := command.Decorate(countingHandler(&attempts, nil),
+			command.Deduplicate(command.NewInMemoryDeduplicationStore()))
+
+		require.NoError(t, handler.Handle(ctx, command.ToEnvelope(commandTest1{})))
+		require.NoError(t, handler.Handle(ctx, command.ToEnvelope(commandTest1{})))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("commands are handled again once the outcome expires", func(t *testing.T) {
+		var attempts int
+
+		handler := command.Decorate(countingHandler(&attempts, nil),
+			command.Deduplicate(command.NewInMemoryDeduplicationStore(),
+				command.WithDeduplicationTTL(time.Millisecond),
+				command.WithDeduplicationKey("Request-ID"),
+			))
+
+		cmd := command.Envelope[commandTest1]{
+			Message:  commandTest1{},
+			Metadata: message.Metadata{"Request-ID": "request"},
+		}
+
+		require.NoError(t, handler.Handle(ctx, cmd))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, handler.Handle(ctx, cmd))
+		assert.Equal(t, 2, attempts)
+	})
+}
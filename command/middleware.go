@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/get-eventually/go-eventually/message"
+)
+
+// GenericHandlerFunc is a functional Handler type that accepts any Command,
+// through a GenericEnvelope.
+type GenericHandlerFunc func(ctx context.Context, cmd GenericEnvelope) error
+
+// Middleware decorates a Command Handler with cross-cutting behavior,
+// such as logging, validation, authorization or panic recovery.
+//
+// Middlewares work on GenericEnvelope values, so that they can be written once
+// and applied to any Handler[T] using Decorate.
+type Middleware func(next GenericHandlerFunc) GenericHandlerFunc
+
+// Chain composes the provided Middlewares into a single one.
+//
+// Middlewares are applied in the order specified: the first Middleware
+// is the outermost one, which means it is the first to receive the Command.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next GenericHandlerFunc) GenericHandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+
+		return next
+	}
+}
+
+// Decorate returns a Handler that runs the provided Middlewares
+// before calling the specified Handler.
+//
+// Middlewares may change the Command Envelope passed to the next one,
+// as long as the Command type is preserved.
+func Decorate[T Command](handler Handler[T], middlewares ...Middleware) Handler[T] {
+	next := Chain(middlewares...)(func(ctx context.Context, cmd GenericEnvelope) error {
+		envelope, ok := FromGenericEnvelope[T](cmd)
+		if !ok {
+			var expected T
+
+			return fmt.Errorf("command.Decorate: unexpected command type %T, expected %T", cmd.Message, expected)
+		}
+
+		return handler.Handle(ctx, envelope)
+	})
+
+	return HandlerFunc[T](func(ctx context.Context, cmd Envelope[T]) error {
+		return next(ctx, cmd.ToGenericEnvelope())
+	})
+}
+
+// PropagateCause is a Middleware that stamps the Command Metadata with
+// its Message, Correlation and Causation IDs (see message.Stamp), and carries it
+// in the context as the cause of all the Messages produced while handling the Command.
+//
+// Repositories use the cause to stamp the Domain Events recorded by the Handler,
+// linking them to the Command that caused them.
+//
+// When used together with Deduplicate, PropagateCause must run after it
+// in the Middleware chain, as it generates an ID for the Commands that have none.
+func PropagateCause(next GenericHandlerFunc) GenericHandlerFunc {
+	return func(ctx context.Context, cmd GenericEnvelope) error {
+		cmd.Metadata = message.Stamp(ctx, cmd.Metadata)
+
+		return next(message.ContextWithCause(ctx, cmd.Metadata), cmd)
+	}
+}
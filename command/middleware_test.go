@@ -0,0 +1,136 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/message"
+)
+
+func recordingMiddleware(name string, calls *[]string) command.Middleware {
+	return func(next command.GenericHandlerFunc) command.GenericHandlerFunc {
+		return func(ctx context.Context, cmd command.GenericEnvelope) error {
+			*calls = append(*calls, name+":"+cmd.Message.Name())
+
+			return next(ctx, cmd)
+		}
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("middlewares are called in order before the handler", func(t *testing.T) {
+		var calls []string
+
+		chain := command.Chain(
+			recordingMiddleware("first", &calls),
+			recordingMiddleware("second", &calls),
+		)
+
+		// The same chain can be applied to handlers of different command types.
+		handler1 := command.Decorate(command.HandlerFunc[commandTest1](
+			func(context.Context, command.Envelope[commandTest1]) error {
+				calls = append(calls, "handler1")
+
+				return nil
+			},
+		), chain)
+
+		handler2 := command.Decorate(command.HandlerFunc[commandTest2](
+			func(context.Context, command.Envelope[commandTest2]) error {
+				calls = append(calls, "handler2")
+
+				return nil
+			},
+		), chain)
+
+		require.NoError(t, handler1.Handle(ctx, command.ToEnvelope(commandTest1{})))
+		require.NoError(t, handler2.Handle(ctx, command.ToEnvelope(commandTest2{})))
+
+		assert.Equal(t, []string{
+			"first:command_test_1", "second:command_test_1", "handler1",
+			"first:command_test_2", "second:command_test_2", "handler2",
+		}, calls)
+	})
+
+	t.Run("middlewares can short-circuit the handler", func(t *testing.T) {
+		errUnauthorized := errors.New("unauthorized")
+
+		authorize := func(next command.GenericHandlerFunc) command.GenericHandlerFunc {
+			return func(ctx context.Context, cmd command.GenericEnvelope) error {
+				if cmd.Metadata["Authorization"] == "" {
+					return errUnauthorized
+				}
+
+				return next(ctx, cmd)
+			}
+		}
+
+		var handled bool
+
+		handler := command.Decorate(command.HandlerFunc[commandTest1](
+			func(context.Context, command.Envelope[commandTest1]) error {
+				handled = true
+
+				return nil
+			},
+		), authorize)
+
+		err := handler.Handle(ctx, command.ToEnvelope(commandTest1{}))
+		require.ErrorIs(t, err, errUnauthorized)
+		assert.False(t, handled)
+
+		require.NoError(t, handler.Handle(ctx, command.Envelope[commandTest1]{
+			Message:  commandTest1{},
+			Metadata: message.Metadata{"Authorization": "Bearer token"},
+		}))
+		assert.True(t, handled)
+	})
+
+	t.Run("middlewares changing the command type make the handler fail", func(t *testing.T) {
+		replace := func(next command.GenericHandlerFunc) command.GenericHandlerFunc {
+			return func(ctx context.Context, cmd command.GenericEnvelope) error {
+				cmd.Message = commandTest2{}
+
+				return next(ctx, cmd)
+			}
+		}
+
+		handler := command.Decorate(command.HandlerFunc[commandTest1](
+			func(context.Context, command.Envelope[commandTest1]) error { return nil },
+		), replace)
+
+		assert.Error(t, handler.Handle(ctx, command.ToEnvelope(commandTest1{})))
+	})
+}
+
+func TestPropagateCause(t *testing.T) {
+	var (
+		handled message.Metadata
+		cause   message.Metadata
+	)
+
+	handler := command.Decorate(command.HandlerFunc[commandTest1](
+		func(ctx context.Context, cmd command.Envelope[commandTest1]) error {
+			handled = cmd.Metadata
+			cause, _ = message.CauseFromContext(ctx)
+
+			return nil
+		},
+	), command.PropagateCause)
+
+	require.NoError(t, handler.Handle(t.Context(), command.Envelope[commandTest1]{
+		Message:  commandTest1{},
+		Metadata: message.Metadata{}.WithCorrelationID("flow"),
+	}))
+
+	assert.NotEmpty(t, handled.MessageID())
+	assert.Equal(t, "flow", handled.CorrelationID())
+	assert.Equal(t, handled, cause)
+}
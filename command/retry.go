@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/get-eventually/go-eventually/version"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of times a Command
+	// is handled by RetryOnConflict, including the first attempt.
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default time RetryOnConflict waits
+	// before handling a Command again for the first time.
+	DefaultRetryInitialBackoff = 10 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default maximum time RetryOnConflict waits
+	// between two attempts.
+	DefaultRetryMaxBackoff = time.Second
+	// DefaultRetryJitter is the default fraction of the backoff that
+	// RetryOnConflict randomizes, to spread concurrent retries.
+	DefaultRetryJitter = 0.2
+)
+
+// RetryNotifyFunc is called by RetryOnConflict every time the handling
+// of a Command fails with a conflict and is about to be retried.
+//
+// The attempt number of the failed attempt is provided, starting from 1.
+type RetryNotifyFunc func(ctx context.Context, cmd GenericEnvelope, attempt int, err error)
+
+// RetryOption can be used to change the configuration of RetryOnConflict.
+type RetryOption interface {
+	apply(*retryConfig)
+}
+
+type retryOption func(*retryConfig)
+
+func (apply retryOption) apply(cfg *retryConfig) { apply(cfg) }
+
+// WithRetryMaxAttempts specifies the number of times a Command is handled,
+// including the first attempt, before giving up.
+func WithRetryMaxAttempts(attempts int) RetryOption {
+	return retryOption(func(cfg *retryConfig) {
+		cfg.maxAttempts = attempts
+	})
+}
+
+// WithRetryBackoff specifies the exponential backoff used between two attempts:
+// the first retry waits for the initial duration, which doubles on every
+// further retry up to max.
+func WithRetryBackoff(initial, maxBackoff time.Duration) RetryOption {
+	return retryOption(func(cfg *retryConfig) {
+		cfg.initialBackoff = initial
+		cfg.maxBackoff = maxBackoff
+	})
+}
+
+// WithRetryJitter specifies the fraction of the backoff, between 0 and 1,
+// which is randomized on every retry: e.g. with 0.2, a retry waits
+// between 80% and 100% of the backoff.
+func WithRetryJitter(jitter float64) RetryOption {
+	return retryOption(func(cfg *retryConfig) {
+		cfg.jitter = max(0, min(jitter, 1))
+	})
+}
+
+// WithRetryNotify specifies a function to call every time a Command is retried.
+//
+// Useful to expose the retries to observability tools, see the opentelemetry package.
+func WithRetryNotify(notify RetryNotifyFunc) RetryOption {
+	return retryOption(func(cfg *retryConfig) {
+		cfg.notify = notify
+	})
+}
+
+type retryConfig struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         float64
+	notify         RetryNotifyFunc
+}
+
+func (cfg retryConfig) delay(backoff time.Duration) time.Duration {
+	randomized := float64(backoff) * cfg.jitter * rand.Float64() //nolint:gosec // No need for a secure source of randomness.
+
+	return backoff - time.Duration(randomized)
+}
+
+// RetryOnConflict returns a Middleware that handles a Command again when
+// the error returned contains a version.ConflictError, e.g. when
+// an aggregate.Repository fails to save an Aggregate Root modified concurrently.
+//
+// This is safe for Command Handlers following the Get/mutate/Save shape,
+// since every attempt loads the latest version of the Aggregate Root.
+//
+// Attempts are spaced by an exponential backoff with jitter. Once the maximum
+// number of attempts is reached, the last error is returned.
+func RetryOnConflict(options ...RetryOption) Middleware {
+	cfg := retryConfig{
+		maxAttempts:    DefaultRetryMaxAttempts,
+		initialBackoff: DefaultRetryInitialBackoff,
+		maxBackoff:     DefaultRetryMaxBackoff,
+		jitter:         DefaultRetryJitter,
+		notify:         nil,
+	}
+
+	for _, opt := range options {
+		opt.apply(&cfg)
+	}
+
+	return func(next GenericHandlerFunc) GenericHandlerFunc {
+		return func(ctx context.Context, cmd GenericEnvelope) error {
+			backoff := cfg.initialBackoff
+
+			for attempt := 1; ; attempt++ {
+				err := next(ctx, cmd)
+
+				var conflictErr version.ConflictError
+				if err == nil || !errors.As(err, &conflictErr) {
+					return err
+				}
+
+				if attempt >= cfg.maxAttempts {
+					return fmt.Errorf("command.RetryOnConflict: giving up after %d attempts, %w", attempt, err)
+				}
+
+				if cfg.notify != nil {
+					cfg.notify(ctx, cmd, attempt, err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("command.RetryOnConflict: context error while retrying, %w (caused by: %w)", ctx.Err(), err)
+				case <-time.After(cfg.delay(backoff)):
+				}
+
+				backoff = min(2*backoff, cfg.maxBackoff)
+			}
+		}
+	}
+}
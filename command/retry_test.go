@@ -0,0 +1,97 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func conflictingHandler(conflicts int, attempts *int) command.Handler[commandTest1] {
+	return command.HandlerFunc[commandTest1](func(context.Context, command.Envelope[commandTest1]) error {
+		if *attempts++; *attempts <= conflicts {
+			return fmt.Errorf("failed to save, %w", version.ConflictError{Expected: 1, Actual: 2})
+		}
+
+		return nil
+	})
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	ctx := context.Background()
+	backoff := command.WithRetryBackoff(time.Millisecond, time.Millisecond)
+
+	t.Run("handlers are retried until they succeed", func(t *testing.T) {
+		var (
+			attempts int
+			notified []int
+		)
+
+		handler := command.Decorate(conflictingHandler(2, &attempts), command.RetryOnConflict(
+			backoff,
+			command.WithRetryNotify(func(_ context.Context, _ command.GenericEnvelope, attempt int, err error) {
+				assert.ErrorAs(t, err, new(version.ConflictError))
+
+				notified = append(notified, attempt)
+			}),
+		))
+
+		require.NoError(t, handler.Handle(ctx, command.ToEnvelope(commandTest1{})))
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, []int{1, 2}, notified)
+	})
+
+	t.Run("the last conflict error is returned after max attempts", func(t *testing.T) {
+		var attempts int
+
+		handler := command.Decorate(conflictingHandler(10, &attempts), command.RetryOnConflict(
+			backoff,
+			command.WithRetryMaxAttempts(4),
+			command.WithRetryJitter(1),
+		))
+
+		err := handler.Handle(ctx, command.ToEnvelope(commandTest1{}))
+		require.ErrorAs(t, err, new(version.ConflictError))
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		var attempts int
+
+		errHandler := errors.New("handler failed")
+
+		handler := command.Decorate(command.HandlerFunc[commandTest1](
+			func(context.Context, command.Envelope[commandTest1]) error {
+				attempts++
+
+				return errHandler
+			},
+		), command.RetryOnConflict(backoff))
+
+		require.ErrorIs(t, handler.Handle(ctx, command.ToEnvelope(commandTest1{})), errHandler)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("retries stop when the context is canceled", func(t *testing.T) {
+		var attempts int
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		handler := command.Decorate(conflictingHandler(10, &attempts), command.RetryOnConflict(
+			command.WithRetryBackoff(time.Minute, time.Minute),
+		))
+
+		err := handler.Handle(ctx, command.ToEnvelope(commandTest1{}))
+		require.ErrorIs(t, err, context.Canceled)
+		assert.ErrorAs(t, err, new(version.ConflictError))
+		assert.Equal(t, 1, attempts)
+	})
+}
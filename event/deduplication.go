@@ -0,0 +1,78 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/get-eventually/go-eventually/version"
+)
+
+// ErrDuplicateEventID is returned by Appender.Append when a Domain Event
+// carries an ID that has already been committed, but not by a previous
+// attempt of the same append.
+var ErrDuplicateEventID = errors.New("event.Appender: duplicate event id")
+
+// MatchRetriedAppend checks whether the Domain Events to append to the specified
+// Event Stream have already been committed by a previous attempt of the same append.
+//
+// Committed are the Domain Events already committed with the same IDs
+// of the Domain Events to append, in Position order.
+//
+// If all the Domain Events have been committed to the Event Stream in the same order
+// and at consecutive versions, the version of the Event Stream before the previous
+// attempt is returned, along with true.
+// ErrDuplicateEventID is returned if only some of them have been committed,
+// or if they have been committed differently.
+//
+// Useful for Event Store implementations.
+func MatchRetriedAppend(id StreamID, events []Envelope, committed []Persisted) (version.Version, bool, error) {
+	if len(committed) == 0 {
+		return 0, false, nil
+	}
+
+	if len(committed) != len(events) {
+		return 0, false, fmt.Errorf("event.MatchRetriedAppend: %d of %d events already committed, %w",
+			len(committed), len(events), ErrDuplicateEventID)
+	}
+
+	previousVersion := committed[0].Version - 1
+
+	for i, evt := range committed {
+		expectedVersion := previousVersion + version.Version(i) + 1 //nolint:gosec // This should not overflow.
+
+		if evt.StreamID != id || evt.Version != expectedVersion || evt.ID() != events[i].ID() {
+			return 0, false, fmt.Errorf("event.MatchRetriedAppend: event %q committed at a different position, %w",
+				evt.ID(), ErrDuplicateEventID)
+		}
+	}
+
+	return previousVersion, true, nil
+}
+
+// CheckUniqueIDs checks that the Domain Events of the appends carry unique IDs,
+// returning ErrDuplicateEventID otherwise. Domain Events with no ID are ignored.
+//
+// Event Store implementations supporting batch appends should check
+// the whole batch before appending, since the Domain Events of an append would
+// otherwise be mistaken for a previous attempt of a later append in the same batch.
+func CheckUniqueIDs(appends ...StreamAppend) error {
+	seen := make(map[string]StreamID)
+
+	for _, app := range appends {
+		for _, evt := range app.Events {
+			id := evt.ID()
+			if id == "" {
+				continue
+			}
+
+			if streamID, ok := seen[id]; ok {
+				return fmt.Errorf("event.CheckUniqueIDs: event %q appended to both %q and %q, %w",
+					id, streamID, app.StreamID, ErrDuplicateEventID)
+			}
+
+			seen[id] = app.StreamID
+		}
+	}
+
+	return nil
+}
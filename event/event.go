@@ -1,6 +1,11 @@
 package event
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/get-eventually/go-eventually/message"
 	"github.com/get-eventually/go-eventually/version"
 )
@@ -18,13 +23,72 @@ type Event message.Message
 // take advantage of the new generics feature introduced with Go 1.18.
 type Envelope message.GenericEnvelope
 
+// RecordedAtMetadataKey is the Metadata key used by Event Store implementations
+// to record the time a Domain Event has been appended, in time.RFC3339Nano format.
+const RecordedAtMetadataKey = "Recorded-At"
+
+// ErrRecordedAtNotFound is returned by Envelope.RecordedAt when the Domain Event
+// Metadata does not contain the RecordedAtMetadataKey.
+var ErrRecordedAtNotFound = errors.New("event.Envelope: recorded-at metadata not found")
+
+// RecordedAt returns the time the Domain Event has been appended to the Event Store,
+// as recorded in its Metadata by the Event Store implementation.
+//
+// ErrRecordedAtNotFound is returned if the Event Store did not record it.
+func (e Envelope) RecordedAt() (time.Time, error) {
+	value, ok := e.Metadata[RecordedAtMetadataKey]
+	if !ok {
+		return time.Time{}, ErrRecordedAtNotFound
+	}
+
+	recordedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("event.Envelope: failed to parse recorded-at metadata, %w", err)
+	}
+
+	return recordedAt, nil
+}
+
+// ID returns the unique identifier of the Domain Event, held in its Metadata
+// under message.MessageIDMetadataKey, or an empty string if missing.
+//
+// Event Stores use it to detect retried appends, see Appender.
+func (e Envelope) ID() string {
+	return e.Metadata.MessageID()
+}
+
+// StampCause marks the provided Domain Events as caused by the Message
+// carried by the context through message.ContextWithCause, if any
+// (see message.Metadata.CausedBy).
+//
+// Repositories use it to link the Domain Events recorded while handling
+// a Command to the Command itself.
+func StampCause(ctx context.Context, events []Envelope) {
+	cause, ok := message.CauseFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	for i := range events {
+		events[i].Metadata = events[i].Metadata.CausedBy(cause)
+	}
+}
+
 // StreamID identifies an Event Stream, which is a log of ordered Domain Events.
 type StreamID string
 
+// Position is the global position of a persisted Domain Event in the
+// Event Store log, across all Event Streams.
+//
+// Positions are monotonically increasing and start from 1, following the
+// order in which the Domain Events have been committed to the Event Store.
+type Position uint64
+
 // Persisted represents an Domain Event that has been persisted into the Event Store.
 type Persisted struct {
 	StreamID
 	version.Version
+	Position
 	Envelope
 }
 
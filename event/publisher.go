@@ -0,0 +1,19 @@
+package event
+
+import (
+	"context"
+)
+
+// Publisher represents a component that can publish persisted Domain Events
+// to external systems, such as a message broker.
+type Publisher interface {
+	Publish(ctx context.Context, event Persisted) error
+}
+
+// PublisherFunc is a functional implementation of the Publisher interface.
+type PublisherFunc func(ctx context.Context, event Persisted) error
+
+// Publish implements the event.Publisher interface.
+func (pf PublisherFunc) Publish(ctx context.Context, event Persisted) error {
+	return pf(ctx, event)
+}
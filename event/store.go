@@ -44,12 +44,73 @@ type Streamer interface {
 	Stream(ctx context.Context, id StreamID, selector version.Selector) *Stream
 }
 
+// SelectAllFromBeginning is a PositionSelector value that will return all
+// Domain Events in the Event Store log.
+var SelectAllFromBeginning = PositionSelector{From: 0}
+
+// PositionSelector specifies which slice of the global Event Store log
+// to select when streaming Domain Events across all Event Streams.
+type PositionSelector struct {
+	From Position
+}
+
+// AllStreamer is an event.Store trait used to stream the global, ordered log
+// of all Domain Events committed to the Event Store, across all Event Streams.
+//
+// Domain Events are yielded in increasing Position order. Useful for building
+// projections and subscriptions that need to observe the whole Event Store.
+//
+// Implementations should respect ctx cancellation between yields by checking
+// ctx.Err() at loop boundaries inside the producer.
+type AllStreamer interface {
+	StreamAll(ctx context.Context, selector PositionSelector) *Stream
+}
+
+// HeadReader is an event.Store trait used to read the Position of the latest
+// Domain Event committed to the global Event Store log, i.e. its head.
+//
+// Useful to know how far behind the log a consumer of an AllStreamer is,
+// without streaming the Domain Events it has not processed yet.
+//
+// Wrappers of other Event Stores, like opentelemetry.InstrumentedEventStore,
+// return an error wrapping errors.ErrUnsupported when the wrapped one does not implement it.
+type HeadReader interface {
+	// Head returns the Position of the latest Domain Event committed,
+	// or zero if the Event Store is empty.
+	Head(ctx context.Context) (Position, error)
+}
+
 // Appender is an event.Store trait used to append new Domain Events in the
 // Event Stream.
+//
+// Domain Events carrying an ID (see Envelope.ID) are appended at most once:
+// retrying an append whose Domain Events have already been committed, with the same IDs
+// and at the same position in the Event Stream, succeeds without appending them again.
+// Appending Domain Events with IDs committed otherwise fails with ErrDuplicateEventID.
 type Appender interface {
 	Append(ctx context.Context, id StreamID, expected version.Check, events ...Envelope) (version.Version, error)
 }
 
+// StreamAppend is an append of Domain Events to an Event Stream,
+// part of a batch append performed through a BatchAppender.
+type StreamAppend struct {
+	StreamID StreamID
+	Expected version.Check
+	Events   []Envelope
+}
+
+// BatchAppender is an event.Store trait used to append new Domain Events
+// to multiple Event Streams atomically: either all the appends are committed, or none.
+//
+// The appends are performed in the order specified, following the same rules
+// of Appender.Append: the same Event Stream can be appended to more than once
+// in the same batch, with the later appends observing the version of the former.
+//
+// The new versions of the Event Streams are returned in the order of the appends.
+type BatchAppender interface {
+	AppendBatch(ctx context.Context, appends ...StreamAppend) ([]version.Version, error)
+}
+
 // Store represents an Event Store, a stateful data source where Domain Events
 // can be safely stored, and easily replayed.
 type Store interface {
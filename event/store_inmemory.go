@@ -1,32 +1,51 @@
 package event
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/get-eventually/go-eventually/version"
 )
 
-// Interface implementation assertion.
-var _ Store = new(InMemoryStore)
+// Interface implementation assertions.
+var (
+	_ Store         = new(InMemoryStore)
+	_ AllStreamer   = new(InMemoryStore)
+	_ BatchAppender = new(InMemoryStore)
+	_ HeadReader    = new(InMemoryStore)
+)
 
 // InMemoryStore is a thread-safe, in-memory event.Store implementation.
+//
+// The time each Domain Event has been appended is recorded in its Metadata,
+// under RecordedAtMetadataKey.
+//
+// Domain Events are kept in memory as they are, with no serialization involved:
+// hence, they are never upcast, and an UpcasterRegistry cannot be used with this store.
 type InMemoryStore struct {
 	mx     sync.RWMutex
-	events map[StreamID][]Envelope
+	events map[StreamID][]Persisted
+	log    []Persisted
+	ids    map[string]Persisted
 }
 
 // NewInMemoryStore creates a new event.InMemoryStore instance.
 func NewInMemoryStore() *InMemoryStore {
 	return &InMemoryStore{
 		mx:     sync.RWMutex{},
-		events: make(map[StreamID][]Envelope),
+		events: make(map[StreamID][]Persisted),
+		log:    nil,
+		ids:    make(map[string]Persisted),
 	}
 }
 
 // Stream returns a Stream over the committed events for the given Event Stream,
-// filtered by the provided version.Selector.
+// filtered by the provided version.Selector and in the order it specifies.
 //
 // The returned Stream holds a read-lock on the underlying store for the
 // duration of iteration; long-paused iterations will block concurrent writers.
@@ -47,22 +66,54 @@ func (es *InMemoryStore) Stream(
 			return nil
 		}
 
-		for i, evt := range events {
+		for i := range events {
+			if selector.Direction == version.Backward {
+				i = len(events) - 1 - i
+			}
+
 			if err := ctx.Err(); err != nil {
 				return fmt.Errorf("event.InMemoryStore: context error, %w", err)
 			}
 
-			eventVersion := version.Version(i) + 1
+			if evt := events[i]; selector.Includes(evt.Version) && !yield(evt) {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// StreamAll returns a Stream over all the committed events in the store,
+// across all Event Streams, in the order they have been appended and
+// filtered by the provided PositionSelector.
+//
+// Unlike Stream, the returned Stream does not hold any lock during iteration:
+// it iterates over the events committed at the time iteration starts, and
+// events appended afterwards are not yielded. This allows consumers to append
+// new events to the store while iterating.
+//
+// Iteration stops if the consumer abandons the range loop or if the context
+// is canceled between yields.
+func (es *InMemoryStore) StreamAll(ctx context.Context, selector PositionSelector) *Stream {
+	return NewStream(func(yield func(Persisted) bool) error {
+		es.mx.RLock()
+		// NOTE: the global log is append-only, so the events already in the slice
+		// are never modified and can be safely read after releasing the lock.
+		log := es.log
+		es.mx.RUnlock()
+
+		start := 0
+		if selector.From > 1 {
+			start = int(min(selector.From-1, Position(len(log)))) //nolint:gosec // This should not overflow.
+		}
 
-			if eventVersion < selector.From {
-				continue
+		for _, evt := range log[start:] {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("event.InMemoryStore: context error, %w", err)
 			}
 
-			if !yield(Persisted{
-				Envelope: evt,
-				StreamID: id,
-				Version:  eventVersion,
-			}) {
+			if !yield(evt) {
 				return nil
 			}
 		}
@@ -71,6 +122,15 @@ func (es *InMemoryStore) Stream(
 	})
 }
 
+// Head returns the Position of the latest Domain Event appended to the store,
+// or zero if the store is empty.
+func (es *InMemoryStore) Head(context.Context) (Position, error) {
+	es.mx.RLock()
+	defer es.mx.RUnlock()
+
+	return Position(len(es.log)), nil
+}
+
 // Append inserts the specified Domain Events into the Event Stream specified
 // by the current instance, returning the new version of the Event Stream.
 //
@@ -83,6 +143,13 @@ func (es *InMemoryStore) Stream(
 //
 // An instance of `version.ConflictError` will be returned if the optimistic locking
 // version check fails against the current version of the Event Stream.
+//
+// `version.NoStream` and `version.StreamExists` can be used to only check
+// whether the Event Stream exists, failing respectively with
+// `version.ErrStreamAlreadyExists` and `version.ErrStreamNotFound`.
+//
+// Retried appends of Domain Events carrying an ID are detected as described
+// in the Appender interface.
 func (es *InMemoryStore) Append(
 	_ context.Context,
 	id StreamID,
@@ -92,17 +159,138 @@ func (es *InMemoryStore) Append(
 	es.mx.Lock()
 	defer es.mx.Unlock()
 
-	currentVersion := version.CheckExact(len(es.events[id])) //nolint:gosec // This should not overflow.
+	currentVersion := version.Version(len(es.events[id])) //nolint:gosec // This should not overflow.
+
+	if err := CheckUniqueIDs(StreamAppend{StreamID: id, Expected: expected, Events: events}); err != nil {
+		return 0, fmt.Errorf("event.InMemoryStore: failed to append events, %w", err)
+	}
+
+	newVersion, retried, err := es.check(id, expected, currentVersion, events)
+	if err != nil {
+		return 0, fmt.Errorf("event.InMemoryStore: failed to append events, %w", err)
+	}
+
+	if !retried {
+		es.commit(id, events)
+	}
+
+	return newVersion, nil
+}
+
+// AppendBatch appends the Domain Events to multiple Event Streams atomically,
+// returning the new versions of the Event Streams in the order of the appends.
+//
+// All the appends are checked as described in Append before committing
+// any Domain Event: if one of them fails, no Domain Event is committed.
+func (es *InMemoryStore) AppendBatch(_ context.Context, appends ...StreamAppend) ([]version.Version, error) {
+	es.mx.Lock()
+	defer es.mx.Unlock()
+
+	var (
+		versions    = make(map[StreamID]version.Version, len(appends))
+		newVersions = make([]version.Version, len(appends))
+		retried     = make([]bool, len(appends))
+	)
 
-	if v, expectsExactVersion := expected.(version.CheckExact); expectsExactVersion && currentVersion != expected {
-		return 0, fmt.Errorf("event.InMemoryStore: failed to append events, %w", version.ConflictError{
-			Expected: version.Version(v),
-			Actual:   version.Version(currentVersion),
-		})
+	if err := CheckUniqueIDs(appends...); err != nil {
+		return nil, fmt.Errorf("event.InMemoryStore: failed to append events, %w", err)
 	}
 
-	es.events[id] = append(es.events[id], events...)
-	newEventStreamVersion := version.Version(len(es.events[id])) //nolint:gosec // This should not overflow.
+	for i, app := range appends {
+		currentVersion, ok := versions[app.StreamID]
+		if !ok {
+			currentVersion = version.Version(len(es.events[app.StreamID])) //nolint:gosec // This should not overflow.
+		}
+
+		newVersion, isRetried, err := es.check(app.StreamID, app.Expected, currentVersion, app.Events)
+		if err != nil {
+			return nil, fmt.Errorf("event.InMemoryStore: failed to append events to stream %q, %w", app.StreamID, err)
+		}
+
+		newVersions[i], retried[i] = newVersion, isRetried
+
+		if !isRetried {
+			versions[app.StreamID] = newVersion
+		}
+	}
+
+	for i, app := range appends {
+		if !retried[i] {
+			es.commit(app.StreamID, app.Events)
+		}
+	}
+
+	return newVersions, nil
+}
+
+// check verifies that the Domain Events can be appended to the Event Stream,
+// currently at the specified version, returning the new version of the Event Stream
+// and whether the append is a retry of an append already committed.
+func (es *InMemoryStore) check(
+	id StreamID,
+	expected version.Check,
+	currentVersion version.Version,
+	events []Envelope,
+) (version.Version, bool, error) {
+	previousVersion, retried, err := MatchRetriedAppend(id, events, es.committed(events))
+	if err != nil {
+		return 0, false, err
+	}
+
+	if retried {
+		if err := expected.Verify(previousVersion); err != nil {
+			return 0, false, ErrDuplicateEventID
+		}
+
+		return previousVersion + version.Version(len(events)), true, nil //nolint:gosec // This should not overflow.
+	}
+
+	if err := expected.Verify(currentVersion); err != nil {
+		return 0, false, err
+	}
+
+	return currentVersion + version.Version(len(events)), false, nil //nolint:gosec // This should not overflow.
+}
+
+// commit appends the Domain Events to the Event Stream and the global log,
+// recording the append time in their Metadata (see RecordedAtMetadataKey).
+func (es *InMemoryStore) commit(id StreamID, events []Envelope) {
+	recordedAt := time.Now().Format(time.RFC3339Nano)
+
+	for _, evt := range events {
+		// NOTE: the Metadata is cloned to leave the caller's Domain Events untouched.
+		evt.Metadata = maps.Clone(evt.Metadata).With(RecordedAtMetadataKey, recordedAt)
+
+		persisted := Persisted{
+			StreamID: id,
+			Version:  version.Version(len(es.events[id])) + 1, //nolint:gosec // This should not overflow.
+			Position: Position(len(es.log)) + 1,
+			Envelope: evt,
+		}
+
+		es.events[id] = append(es.events[id], persisted)
+		es.log = append(es.log, persisted)
+
+		if eventID := evt.ID(); eventID != "" {
+			es.ids[eventID] = persisted
+		}
+	}
+}
+
+// committed returns the Domain Events already committed with the same IDs
+// of the specified ones, in Position order.
+func (es *InMemoryStore) committed(events []Envelope) []Persisted {
+	var committed []Persisted
+
+	for _, evt := range events {
+		if persisted, ok := es.ids[evt.ID()]; ok && evt.ID() != "" {
+			committed = append(committed, persisted)
+		}
+	}
+
+	slices.SortFunc(committed, func(a, b Persisted) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
 
-	return newEventStreamVersion, nil
+	return committed
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
 	"github.com/get-eventually/go-eventually/message"
 	"github.com/get-eventually/go-eventually/version"
 )
@@ -81,6 +82,28 @@ func TestInMemoryStore_Stream_SelectorFiltersFromVersion(t *testing.T) {
 	assert.Equal(t, []int{2, 3, 4}, got)
 }
 
+func TestInMemoryStore_Stream_SelectorFiltersUpToVersion(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 5)
+
+	stream := store.Stream(t.Context(), testStreamID, version.Selector{From: 2, To: 4, Direction: version.Forward})
+	got := collectIDs(stream)
+
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestInMemoryStore_Stream_SelectorBackward(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 5)
+
+	stream := store.Stream(t.Context(), testStreamID, version.Selector{From: 0, To: 4, Direction: version.Backward})
+	got := collectIDs(stream)
+
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []int{3, 2, 1, 0}, got)
+}
+
 func TestInMemoryStore_Stream_ConsumerAbandonment(t *testing.T) {
 	store := event.NewInMemoryStore()
 	appendN(t, store, 10)
@@ -119,3 +142,112 @@ func TestInMemoryStore_Stream_ContextCancellation(t *testing.T) {
 	require.ErrorIs(t, stream.Err(), context.Canceled)
 	assert.Equal(t, 0, count)
 }
+
+func TestInMemoryStore_Stream_YieldsPositions(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 2)
+
+	_, err := store.Append(t.Context(), "other", version.Any, event.ToEnvelope(noopMessage{id: 2}))
+	require.NoError(t, err)
+
+	stream := store.Stream(t.Context(), "other", version.SelectFromBeginning)
+
+	got := make([]event.Persisted, 0, 1)
+	for evt := range stream.Iter() {
+		got = append(got, evt)
+	}
+
+	require.NoError(t, stream.Err())
+	require.Len(t, got, 1)
+	assert.Equal(t, version.Version(1), got[0].Version)
+	assert.Equal(t, event.Position(3), got[0].Position)
+}
+
+func TestInMemoryStore_StreamAll_YieldsEventsAcrossStreams(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 2)
+
+	_, err := store.Append(t.Context(), "other", version.Any, event.ToEnvelope(noopMessage{id: 2}))
+	require.NoError(t, err)
+
+	appendN(t, store, 1)
+
+	stream := store.StreamAll(t.Context(), event.SelectAllFromBeginning)
+
+	got := make([]event.Persisted, 0, 4)
+	for evt := range stream.Iter() {
+		got = append(got, evt)
+	}
+
+	require.NoError(t, stream.Err())
+	require.Len(t, got, 4)
+
+	expectedStreams := []event.StreamID{testStreamID, testStreamID, "other", testStreamID}
+	expectedVersions := []version.Version{1, 2, 1, 3}
+
+	for i, evt := range got {
+		assert.Equal(t, event.Position(i+1), evt.Position) //nolint:gosec // This should not overflow.
+		assert.Equal(t, expectedStreams[i], evt.StreamID)
+		assert.Equal(t, expectedVersions[i], evt.Version)
+	}
+}
+
+func TestInMemoryStore_StreamAll_SelectorFiltersFromPosition(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 5)
+
+	stream := store.StreamAll(t.Context(), event.PositionSelector{From: 4})
+	got := collectIDs(stream)
+
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []int{3, 4}, got)
+
+	stream = store.StreamAll(t.Context(), event.PositionSelector{From: 10})
+	got = collectIDs(stream)
+
+	require.NoError(t, stream.Err())
+	assert.Empty(t, got)
+}
+
+func TestInMemoryStore_StreamAll_AllowsAppendsWhileIterating(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 3)
+
+	stream := store.StreamAll(t.Context(), event.SelectAllFromBeginning)
+
+	count := 0
+	for range stream.Iter() {
+		count++
+
+		appendN(t, store, 1)
+	}
+
+	require.NoError(t, stream.Err())
+	assert.Equal(t, 3, count, "events appended during iteration should not be yielded")
+}
+
+func TestInMemoryStore_StreamAll_ContextCancellation(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendN(t, store, 5)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	stream := store.StreamAll(ctx, event.SelectAllFromBeginning)
+
+	count := 0
+	for range stream.Iter() {
+		count++
+	}
+
+	require.ErrorIs(t, stream.Err(), context.Canceled)
+	assert.Equal(t, 0, count)
+}
+
+func TestInMemoryStore_AllStreamerSuite(t *testing.T) {
+	user.AllStreamerSuite(event.NewInMemoryStore())(t)
+}
+
+func TestInMemoryStore_EventStoreSuite(t *testing.T) {
+	user.EventStoreSuite(event.NewInMemoryStore())(t)
+}
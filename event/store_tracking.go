@@ -38,6 +38,8 @@ func NewTrackingStore(store Store) *TrackingStore {
 // Each returned Persisted event carries the Version assigned by the wrapped
 // Event Store, reconstructed from the version returned by Append and the
 // order in which events were appended.
+//
+// The global Position is not tracked, and is always left to its zero value.
 func (es *TrackingStore) Recorded() []Persisted {
 	es.mx.RLock()
 	defer es.mx.RUnlock()
@@ -69,6 +71,7 @@ func (es *TrackingStore) Append(
 		es.recorded = append(es.recorded, Persisted{
 			StreamID: id,
 			Version:  previousVersion + version.Version(i) + 1,
+			Position: 0,
 			Envelope: evt,
 		})
 	}
@@ -0,0 +1,172 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+
+	"github.com/get-eventually/go-eventually/message"
+)
+
+// SchemaVersionMetadataKey is the Metadata key used to record the schema version
+// of the serialized payload of a Domain Event.
+//
+// Domain Events without this key are considered to be at schema version 1.
+const SchemaVersionMetadataKey = "Schema-Version"
+
+// InitialSchemaVersion is the schema version of a Domain Event type
+// that has no Upcaster registered.
+const InitialSchemaVersion = 1
+
+var (
+	// ErrUpcasterAlreadyRegistered is returned by UpcasterRegistry.Register when
+	// an Upcaster has already been registered for the same type name and schema version.
+	ErrUpcasterAlreadyRegistered = errors.New("event.UpcasterRegistry: upcaster already registered")
+
+	// ErrInvalidSchemaVersion is returned when a schema version lower than
+	// InitialSchemaVersion is used, or when the schema version recorded
+	// in the Domain Event Metadata cannot be parsed.
+	ErrInvalidSchemaVersion = errors.New("event.UpcasterRegistry: invalid schema version")
+
+	// ErrUpcasterNotFound is returned by UpcasterRegistry.Upcast when no Upcaster
+	// has been registered for a schema version lower than the current one,
+	// i.e. the chain of the registered Upcasters has a gap.
+	ErrUpcasterNotFound = errors.New("event.UpcasterRegistry: upcaster not found")
+)
+
+// Upcaster transforms the serialized payload of a Domain Event from
+// a schema version to the next one.
+type Upcaster interface {
+	Upcast(data []byte) ([]byte, error)
+}
+
+// UpcasterFunc is a functional implementation of the Upcaster interface.
+type UpcasterFunc func(data []byte) ([]byte, error)
+
+// Upcast implements the event.Upcaster interface.
+func (fn UpcasterFunc) Upcast(data []byte) ([]byte, error) { return fn(data) }
+
+type upcasterKey struct {
+	name          string
+	schemaVersion int
+}
+
+// UpcasterRegistry holds the Upcasters registered for each Domain Event type,
+// keyed by type name and the schema version they upcast from.
+//
+// The current schema version of a Domain Event type is the one following
+// the highest schema version an Upcaster has been registered for.
+//
+// Event Store implementations that persist serialized Domain Events can use
+// WithSchemaVersion on append, and Upcast on read, before deserializing
+// the payload into a message.Message. Stores keeping Domain Events in memory,
+// like InMemoryStore, do not serialize them and have no use for upcasting.
+type UpcasterRegistry struct {
+	mx        sync.RWMutex
+	upcasters map[upcasterKey]Upcaster
+	current   map[string]int
+}
+
+// NewUpcasterRegistry returns a new, empty UpcasterRegistry instance.
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{
+		mx:        sync.RWMutex{},
+		upcasters: make(map[upcasterKey]Upcaster),
+		current:   make(map[string]int),
+	}
+}
+
+// Register registers an Upcaster transforming payloads of the Domain Event type
+// with the specified name from the specified schema version to the next one.
+func (r *UpcasterRegistry) Register(name string, fromSchemaVersion int, upcaster Upcaster) error {
+	if fromSchemaVersion < InitialSchemaVersion {
+		return fmt.Errorf("event.UpcasterRegistry: failed to register upcaster for '%s', %w: %d",
+			name, ErrInvalidSchemaVersion, fromSchemaVersion)
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	key := upcasterKey{name: name, schemaVersion: fromSchemaVersion}
+
+	if _, ok := r.upcasters[key]; ok {
+		return fmt.Errorf("event.UpcasterRegistry: failed to register upcaster for '%s' v%d, %w",
+			name, fromSchemaVersion, ErrUpcasterAlreadyRegistered)
+	}
+
+	r.upcasters[key] = upcaster
+	r.current[name] = max(r.current[name], fromSchemaVersion+1)
+
+	return nil
+}
+
+// SchemaVersion returns the current schema version of the Domain Event type
+// with the specified name.
+func (r *UpcasterRegistry) SchemaVersion(name string) int {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	if v, ok := r.current[name]; ok {
+		return v
+	}
+
+	return InitialSchemaVersion
+}
+
+// WithSchemaVersion returns a copy of the provided Metadata recording the current
+// schema version of the Domain Event type with the specified name.
+func (r *UpcasterRegistry) WithSchemaVersion(name string, metadata message.Metadata) message.Metadata {
+	return maps.Clone(metadata).With(SchemaVersionMetadataKey, strconv.Itoa(r.SchemaVersion(name)))
+}
+
+// Upcast transforms the serialized payload of a Domain Event with the specified
+// type name, from the schema version recorded in its Metadata to the current one,
+// applying all the registered Upcasters in sequence.
+//
+// ErrUpcasterNotFound is returned if the Upcaster from one of the schema versions
+// in between has not been registered.
+//
+// The returned Metadata is a copy of the one provided, recording
+// the schema version of the returned payload.
+func (r *UpcasterRegistry) Upcast(
+	name string,
+	metadata message.Metadata,
+	data []byte,
+) ([]byte, message.Metadata, error) {
+	schemaVersion := InitialSchemaVersion
+
+	if value, ok := metadata[SchemaVersionMetadataKey]; ok {
+		v, err := strconv.Atoi(value)
+		if err != nil || v < InitialSchemaVersion {
+			return nil, nil, fmt.Errorf("event.UpcasterRegistry: failed to upcast '%s', %w: '%s'",
+				name, ErrInvalidSchemaVersion, value)
+		}
+
+		schemaVersion = v
+	}
+
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	current := r.current[name]
+
+	for schemaVersion < current {
+		upcaster, ok := r.upcasters[upcasterKey{name: name, schemaVersion: schemaVersion}]
+		if !ok {
+			return nil, nil, fmt.Errorf("event.UpcasterRegistry: failed to upcast '%s' from v%d to v%d, %w",
+				name, schemaVersion, current, ErrUpcasterNotFound)
+		}
+
+		var err error
+		if data, err = upcaster.Upcast(data); err != nil {
+			return nil, nil, fmt.Errorf("event.UpcasterRegistry: failed to upcast '%s' from v%d, %w",
+				name, schemaVersion, err)
+		}
+
+		schemaVersion++
+	}
+
+	return data, maps.Clone(metadata).With(SchemaVersionMetadataKey, strconv.Itoa(schemaVersion)), nil
+}
@@ -0,0 +1,126 @@
+package event_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/message"
+)
+
+func appendSuffix(suffix string) event.UpcasterFunc {
+	return func(data []byte) ([]byte, error) {
+		return append(bytes.Clone(data), suffix...), nil
+	}
+}
+
+func TestUpcasterRegistry(t *testing.T) {
+	t.Run("register fails on invalid schema version", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		err := registry.Register("noop", 0, appendSuffix("-v1"))
+		assert.ErrorIs(t, err, event.ErrInvalidSchemaVersion)
+	})
+
+	t.Run("register fails when the upcaster is already registered", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		require.NoError(t, registry.Register("noop", 1, appendSuffix("-v2")))
+
+		err := registry.Register("noop", 1, appendSuffix("-v2"))
+		assert.ErrorIs(t, err, event.ErrUpcasterAlreadyRegistered)
+	})
+
+	t.Run("schema version follows the registered upcasters", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		assert.Equal(t, event.InitialSchemaVersion, registry.SchemaVersion("noop"))
+
+		require.NoError(t, registry.Register("noop", 1, appendSuffix("-v2")))
+		require.NoError(t, registry.Register("noop", 2, appendSuffix("-v3")))
+
+		assert.Equal(t, 3, registry.SchemaVersion("noop"))
+		assert.Equal(t, event.InitialSchemaVersion, registry.SchemaVersion("other"))
+
+		metadata := message.Metadata{"key": "value"}
+		enriched := registry.WithSchemaVersion("noop", metadata)
+
+		assert.Equal(t, message.Metadata{
+			"key":                          "value",
+			event.SchemaVersionMetadataKey: "3",
+		}, enriched)
+		assert.NotContains(t, metadata, event.SchemaVersionMetadataKey)
+	})
+
+	t.Run("upcast applies all the upcasters from the recorded schema version", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		require.NoError(t, registry.Register("noop", 1, appendSuffix("-v2")))
+		require.NoError(t, registry.Register("noop", 2, appendSuffix("-v3")))
+
+		data, metadata, err := registry.Upcast("noop", nil, []byte("v1"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1-v2-v3"), data)
+		assert.Equal(t, "3", metadata[event.SchemaVersionMetadataKey])
+
+		data, metadata, err = registry.Upcast("noop", message.Metadata{
+			event.SchemaVersionMetadataKey: "2",
+		}, []byte("v2"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2-v3"), data)
+		assert.Equal(t, "3", metadata[event.SchemaVersionMetadataKey])
+
+		data, _, err = registry.Upcast("noop", message.Metadata{
+			event.SchemaVersionMetadataKey: "3",
+		}, []byte("v3"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v3"), data)
+	})
+
+	t.Run("upcast fails when the chain of upcasters has a gap", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		require.NoError(t, registry.Register("noop", 1, appendSuffix("-v2")))
+		require.NoError(t, registry.Register("noop", 3, appendSuffix("-v4")))
+		assert.Equal(t, 4, registry.SchemaVersion("noop"))
+
+		_, _, err := registry.Upcast("noop", nil, []byte("v1"))
+		require.ErrorIs(t, err, event.ErrUpcasterNotFound)
+
+		_, _, err = registry.Upcast("noop", message.Metadata{
+			event.SchemaVersionMetadataKey: "2",
+		}, []byte("v2"))
+		require.ErrorIs(t, err, event.ErrUpcasterNotFound)
+
+		data, _, err := registry.Upcast("noop", message.Metadata{
+			event.SchemaVersionMetadataKey: "3",
+		}, []byte("v3"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v3-v4"), data)
+	})
+
+	t.Run("upcast fails on invalid recorded schema version", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+
+		_, _, err := registry.Upcast("noop", message.Metadata{
+			event.SchemaVersionMetadataKey: "not-a-number",
+		}, []byte("v1"))
+		assert.ErrorIs(t, err, event.ErrInvalidSchemaVersion)
+	})
+
+	t.Run("upcast fails when an upcaster fails", func(t *testing.T) {
+		registry := event.NewUpcasterRegistry()
+		upcasterErr := errors.New("upcast failed")
+
+		require.NoError(t, registry.Register("noop", 1, event.UpcasterFunc(func([]byte) ([]byte, error) {
+			return nil, upcasterErr
+		})))
+
+		_, _, err := registry.Upcast("noop", nil, []byte("v1"))
+		assert.ErrorIs(t, err, upcasterErr)
+	})
+}
@@ -64,5 +64,240 @@ func EventStoreSuite(eventStore event.Store) func(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, expectedVersion, newVersion)
 		})
+
+		t.Run("append checks whether the event stream exists", func(t *testing.T) {
+			id := uuid.New()
+			streamID := event.StreamID(id.String())
+
+			usr, err := Create(id, "Dani", "Ross", "dani@ross.com", now, now)
+			require.NoError(t, err)
+
+			created := usr.FlushRecordedEvents()
+
+			_, err = eventStore.Append(ctx, streamID, version.StreamExists, created...)
+			require.ErrorIs(t, err, version.ErrStreamNotFound)
+
+			_, err = eventStore.Append(ctx, streamID, version.NoStream, created...)
+			require.NoError(t, err)
+
+			_, err = eventStore.Append(ctx, streamID, version.NoStream, created...)
+			require.ErrorIs(t, err, version.ErrStreamAlreadyExists)
+
+			require.NoError(t, usr.UpdateEmail("dani.ross@mail.com", now, nil))
+
+			newVersion, err := eventStore.Append(ctx, streamID, version.StreamExists, usr.FlushRecordedEvents()...)
+			require.NoError(t, err)
+			require.Equal(t, version.Version(2), newVersion)
+		})
+
+		t.Run("retried appends of events with ids are a no-op", func(t *testing.T) {
+			id := uuid.New()
+			streamID := event.StreamID(id.String())
+
+			usr, err := Create(id, "Dani", "Ross", "dani@ross.com", now, now)
+			require.NoError(t, err)
+			require.NoError(t, usr.UpdateEmail("dani.ross@mail.com", now, nil))
+
+			events := usr.FlushRecordedEvents()
+			for i := range events {
+				events[i].Metadata = events[i].Metadata.WithMessageID(uuid.NewString())
+			}
+
+			newVersion, err := eventStore.Append(ctx, streamID, version.CheckExact(0), events...)
+			require.NoError(t, err)
+			require.Equal(t, version.Version(2), newVersion)
+
+			newVersion, err = eventStore.Append(ctx, streamID, version.CheckExact(0), events...)
+			require.NoError(t, err)
+			require.Equal(t, version.Version(2), newVersion)
+
+			_, err = eventStore.Append(ctx, streamID, version.CheckExact(2), events[1])
+			require.ErrorIs(t, err, event.ErrDuplicateEventID)
+
+			_, err = eventStore.Append(ctx, event.StreamID(uuid.NewString()), version.Any, events...)
+			require.ErrorIs(t, err, event.ErrDuplicateEventID)
+
+			var versions []version.Version
+			for evt := range eventStore.Stream(ctx, streamID, version.SelectFromBeginning).Iter() {
+				versions = append(versions, evt.Version)
+			}
+
+			require.Equal(t, []version.Version{1, 2}, versions)
+		})
+
+		if batchAppender, ok := eventStore.(event.BatchAppender); ok {
+			t.Run("batch appends are committed atomically", func(t *testing.T) {
+				firstID, secondID := uuid.New(), uuid.New()
+
+				first, err := Create(firstID, "Dani", "Ross", "dani@ross.com", now, now)
+				require.NoError(t, err)
+
+				second, err := Create(secondID, "John", "Doe", "john@doe.com", now, now)
+				require.NoError(t, err)
+
+				firstEvents, secondEvents := first.FlushRecordedEvents(), second.FlushRecordedEvents()
+
+				_, err = batchAppender.AppendBatch(ctx,
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.NoStream, Events: firstEvents},
+					event.StreamAppend{StreamID: event.StreamID(secondID.String()), Expected: version.StreamExists, Events: secondEvents},
+				)
+				require.ErrorIs(t, err, version.ErrStreamNotFound)
+
+				for evt := range eventStore.Stream(ctx, event.StreamID(firstID.String()), version.SelectFromBeginning).Iter() {
+					require.Failf(t, "unexpected event committed", "%v", evt)
+				}
+
+				require.NoError(t, first.UpdateEmail("dani.ross@mail.com", now, nil))
+				firstEvents = append(firstEvents, first.FlushRecordedEvents()...)
+
+				newVersions, err := batchAppender.AppendBatch(ctx,
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.NoStream, Events: firstEvents[:1]},
+					event.StreamAppend{StreamID: event.StreamID(secondID.String()), Expected: version.NoStream, Events: secondEvents},
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.CheckExact(1), Events: firstEvents[1:]},
+				)
+				require.NoError(t, err)
+				require.Equal(t, []version.Version{1, 1, 2}, newVersions)
+			})
+
+			t.Run("batch appends reject duplicate event ids across appends", func(t *testing.T) {
+				firstID, secondID := uuid.New(), uuid.New()
+
+				first, err := Create(firstID, "Dani", "Ross", "dani@ross.com", now, now)
+				require.NoError(t, err)
+
+				events := first.FlushRecordedEvents()
+				events[0].Metadata = events[0].Metadata.WithMessageID(uuid.NewString())
+
+				_, err = batchAppender.AppendBatch(ctx,
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.NoStream, Events: events},
+					event.StreamAppend{StreamID: event.StreamID(secondID.String()), Expected: version.NoStream, Events: events},
+				)
+				require.ErrorIs(t, err, event.ErrDuplicateEventID)
+
+				_, err = batchAppender.AppendBatch(ctx,
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.NoStream, Events: events},
+					event.StreamAppend{StreamID: event.StreamID(firstID.String()), Expected: version.Any, Events: events},
+				)
+				require.ErrorIs(t, err, event.ErrDuplicateEventID)
+
+				for _, id := range []uuid.UUID{firstID, secondID} {
+					for evt := range eventStore.Stream(ctx, event.StreamID(id.String()), version.SelectFromBeginning).Iter() {
+						require.Failf(t, "unexpected event committed", "%v", evt)
+					}
+				}
+			})
+		}
+
+		t.Run("stream can be read within bounds and backwards", func(t *testing.T) {
+			id := uuid.New()
+			streamID := event.StreamID(id.String())
+
+			usr, err := Create(id, "Dani", "Ross", "dani@ross.com", now, now)
+			require.NoError(t, err)
+			require.NoError(t, usr.UpdateEmail("dani.ross@mail.com", now, nil))
+			require.NoError(t, usr.UpdateEmail("daniross123@gmail.com", now, nil))
+
+			_, err = eventStore.Append(ctx, streamID, version.CheckExact(0), usr.FlushRecordedEvents()...)
+			require.NoError(t, err)
+
+			collectVersions := func(selector version.Selector) []version.Version {
+				stream := eventStore.Stream(ctx, streamID, selector)
+
+				var versions []version.Version
+				for evt := range stream.Iter() {
+					versions = append(versions, evt.Version)
+				}
+
+				require.NoError(t, stream.Err())
+
+				return versions
+			}
+
+			require.Equal(t, []version.Version{1, 2}, collectVersions(version.Selector{
+				From: 0, To: 2, Direction: version.Forward,
+			}))
+
+			require.Equal(t, []version.Version{3, 2, 1}, collectVersions(version.Selector{
+				From: 0, To: 0, Direction: version.Backward,
+			}))
+
+			require.Equal(t, []version.Version{2}, collectVersions(version.Selector{
+				From: 2, To: 2, Direction: version.Backward,
+			}))
+		})
+	}
+}
+
+// GlobalLogEventStore is an event.Store that also supports streaming
+// the global Event Store log, through the event.AllStreamer interface.
+type GlobalLogEventStore interface {
+	event.Store
+	event.AllStreamer
+}
+
+// AllStreamerSuite returns an executable testing suite running on the
+// event.AllStreamer capabilities of the event.Store value provided in input.
+//
+// The suite does not expect the Event Store to be empty.
+func AllStreamerSuite(eventStore GlobalLogEventStore) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Helper()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		t.Run("stream all yields events across streams in committed order", func(t *testing.T) {
+			var lastPosition event.Position
+
+			stream := eventStore.StreamAll(ctx, event.SelectAllFromBeginning)
+			for evt := range stream.Iter() {
+				require.Greater(t, evt.Position, lastPosition)
+				lastPosition = evt.Position
+			}
+
+			require.NoError(t, stream.Err())
+
+			firstID, secondID := uuid.New(), uuid.New()
+
+			for _, id := range []uuid.UUID{firstID, secondID, firstID} {
+				usr, err := Create(id, "Dani", "Ross", "dani@ross.com", now, now)
+				require.NoError(t, err)
+
+				_, err = eventStore.Append(ctx, event.StreamID(id.String()), version.Any, usr.FlushRecordedEvents()...)
+				require.NoError(t, err)
+			}
+
+			stream = eventStore.StreamAll(ctx, event.PositionSelector{From: lastPosition + 1})
+
+			var got []event.Persisted
+			for evt := range stream.Iter() {
+				got = append(got, evt)
+			}
+
+			require.NoError(t, stream.Err())
+			require.Len(t, got, 3) //nolint:mnd // False positive.
+
+			expectedStreams := []event.StreamID{
+				event.StreamID(firstID.String()),
+				event.StreamID(secondID.String()),
+				event.StreamID(firstID.String()),
+			}
+
+			expectedVersions := []version.Version{1, 1, 2}
+
+			for i, evt := range got {
+				require.Greater(t, evt.Position, lastPosition)
+				require.Equal(t, expectedStreams[i], evt.StreamID)
+				require.Equal(t, expectedVersions[i], evt.Version)
+
+				lastPosition = evt.Position
+			}
+
+			if reader, ok := eventStore.(event.HeadReader); ok {
+				head, err := reader.Head(ctx)
+				require.NoError(t, err)
+				require.Equal(t, lastPosition, head)
+			}
+		})
 	}
 }
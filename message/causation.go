@@ -0,0 +1,131 @@
+package message
+
+import (
+	"context"
+	"maps"
+
+	"github.com/google/uuid"
+)
+
+// Metadata keys used to trace the flow of Messages in a system.
+const (
+	// MessageIDMetadataKey is the Metadata key holding the unique identifier
+	// of a Message.
+	MessageIDMetadataKey = "Message-ID"
+
+	// CorrelationIDMetadataKey is the Metadata key holding the identifier
+	// of the business flow a Message belongs to, usually the Message ID
+	// of the first Message of the flow.
+	CorrelationIDMetadataKey = "Correlation-ID"
+
+	// CausationIDMetadataKey is the Metadata key holding the Message ID
+	// of the Message that caused a Message to happen.
+	CausationIDMetadataKey = "Causation-ID"
+)
+
+// NewID returns a new, random Message ID.
+func NewID() string {
+	return uuid.NewString()
+}
+
+// MessageID returns the Message ID held by the Metadata, if any.
+func (m Metadata) MessageID() string {
+	return m[MessageIDMetadataKey]
+}
+
+// CorrelationID returns the Correlation ID held by the Metadata, if any.
+func (m Metadata) CorrelationID() string {
+	return m[CorrelationIDMetadataKey]
+}
+
+// CausationID returns the Causation ID held by the Metadata, if any.
+func (m Metadata) CausationID() string {
+	return m[CausationIDMetadataKey]
+}
+
+// WithMessageID returns a new Metadata reference holding the specified Message ID.
+func (m Metadata) WithMessageID(id string) Metadata {
+	return m.With(MessageIDMetadataKey, id)
+}
+
+// WithCorrelationID returns a new Metadata reference holding the specified Correlation ID.
+func (m Metadata) WithCorrelationID(id string) Metadata {
+	return m.With(CorrelationIDMetadataKey, id)
+}
+
+// WithCausationID returns a new Metadata reference holding the specified Causation ID.
+func (m Metadata) WithCausationID(id string) Metadata {
+	return m.With(CausationIDMetadataKey, id)
+}
+
+// EnsureIDs returns a copy of the Metadata holding a Message ID and a Correlation ID,
+// generating a new Message ID if missing, and using the Message ID as Correlation ID
+// if missing, as the Message starts a new business flow.
+func (m Metadata) EnsureIDs() Metadata {
+	metadata := maps.Clone(m)
+
+	if metadata.MessageID() == "" {
+		metadata = metadata.WithMessageID(NewID())
+	}
+
+	if metadata.CorrelationID() == "" {
+		metadata = metadata.WithCorrelationID(metadata.MessageID())
+	}
+
+	return metadata
+}
+
+// CausedBy returns a copy of the Metadata marked as caused by the Message
+// with the provided cause Metadata.
+//
+// The Correlation ID of the cause is inherited (falling back to its Message ID)
+// and the Causation ID is set to the Message ID of the cause.
+// Missing IDs are then generated as in EnsureIDs.
+func (m Metadata) CausedBy(cause Metadata) Metadata {
+	metadata := maps.Clone(m)
+
+	correlationID := cause.CorrelationID()
+	if correlationID == "" {
+		correlationID = cause.MessageID()
+	}
+
+	if correlationID != "" {
+		metadata = metadata.WithCorrelationID(correlationID)
+	}
+
+	if causationID := cause.MessageID(); causationID != "" {
+		metadata = metadata.WithCausationID(causationID)
+	}
+
+	return metadata.EnsureIDs()
+}
+
+type causeContextKey struct{}
+
+// ContextWithCause returns a new context carrying the Metadata of the Message
+// currently being handled, which is the cause of all the Messages produced
+// while handling it.
+//
+// Use CauseFromContext or Stamp to access it.
+func ContextWithCause(ctx context.Context, cause Metadata) context.Context {
+	return context.WithValue(ctx, causeContextKey{}, cause)
+}
+
+// CauseFromContext returns the Metadata of the Message being handled,
+// carried by the context through ContextWithCause, if any.
+func CauseFromContext(ctx context.Context) (Metadata, bool) {
+	cause, ok := ctx.Value(causeContextKey{}).(Metadata)
+
+	return cause, ok
+}
+
+// Stamp returns a copy of the Metadata of a Message produced in the provided context,
+// marked as caused by the Message carried by the context, if any,
+// or as the start of a new business flow otherwise.
+func Stamp(ctx context.Context, metadata Metadata) Metadata {
+	if cause, ok := CauseFromContext(ctx); ok {
+		return metadata.CausedBy(cause)
+	}
+
+	return metadata.EnsureIDs()
+}
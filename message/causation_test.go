@@ -0,0 +1,69 @@
+package message_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/message"
+)
+
+func TestMetadata_EnsureIDs(t *testing.T) {
+	t.Run("missing ids are generated, starting a new flow", func(t *testing.T) {
+		metadata := message.Metadata(nil).EnsureIDs()
+
+		assert.NotEmpty(t, metadata.MessageID())
+		assert.Equal(t, metadata.MessageID(), metadata.CorrelationID())
+		assert.Empty(t, metadata.CausationID())
+	})
+
+	t.Run("existing ids are preserved", func(t *testing.T) {
+		original := message.Metadata{}.WithMessageID("msg").WithCorrelationID("flow")
+		metadata := original.EnsureIDs()
+
+		assert.Equal(t, "msg", metadata.MessageID())
+		assert.Equal(t, "flow", metadata.CorrelationID())
+	})
+}
+
+func TestMetadata_CausedBy(t *testing.T) {
+	t.Run("correlation id is inherited from the cause", func(t *testing.T) {
+		cause := message.Metadata{}.WithMessageID("cause").WithCorrelationID("flow")
+		original := message.Metadata{"Test-Key": "test-value"}
+
+		metadata := original.CausedBy(cause)
+
+		assert.NotEmpty(t, metadata.MessageID())
+		assert.NotEqual(t, "cause", metadata.MessageID())
+		assert.Equal(t, "flow", metadata.CorrelationID())
+		assert.Equal(t, "cause", metadata.CausationID())
+		assert.Equal(t, "test-value", metadata["Test-Key"])
+		assert.Equal(t, message.Metadata{"Test-Key": "test-value"}, original, "original metadata should not be changed")
+	})
+
+	t.Run("cause message id is used as correlation id if missing", func(t *testing.T) {
+		metadata := message.Metadata(nil).CausedBy(message.Metadata{}.WithMessageID("cause"))
+
+		assert.Equal(t, "cause", metadata.CorrelationID())
+		assert.Equal(t, "cause", metadata.CausationID())
+	})
+}
+
+func TestStamp(t *testing.T) {
+	_, ok := message.CauseFromContext(t.Context())
+	require.False(t, ok)
+
+	metadata := message.Stamp(t.Context(), nil)
+	assert.Equal(t, metadata.MessageID(), metadata.CorrelationID())
+
+	ctx := message.ContextWithCause(t.Context(), metadata)
+
+	cause, ok := message.CauseFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, metadata, cause)
+
+	caused := message.Stamp(ctx, nil)
+	assert.Equal(t, metadata.CorrelationID(), caused.CorrelationID())
+	assert.Equal(t, metadata.MessageID(), caused.CausationID())
+}
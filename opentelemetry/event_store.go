@@ -2,6 +2,7 @@ package opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,14 +19,23 @@ import (
 const (
 	EventStreamIDKey              attribute.Key = "event_stream.id"
 	EventStreamVersionSelectorKey attribute.Key = "event_stream.select_from_version"
+	EventStreamVersionSelectToKey attribute.Key = "event_stream.select_to_version"
+	EventStreamDirectionKey       attribute.Key = "event_stream.select_direction"
 	EventStreamExpectedVersionKey attribute.Key = "event_stream.expected_version"
+	EventStreamVersionCheckKey    attribute.Key = "event_stream.version_check"
 	EventStoreNumEventsKey        attribute.Key = "event_store.num_events"
+	EventStoreNumAppendsKey       attribute.Key = "event_store.num_appends"
+	EventStoreFromPositionKey     attribute.Key = "event_store.select_from_position"
+	EventStoreHeadKey             attribute.Key = "event_store.head"
 )
 
 // Span names emitted by the InstrumentedEventStore instrumentation.
 const (
-	EventStoreStreamSpanName = "event.Store.Stream"
-	EventStoreAppendSpanName = "event.Store.Append"
+	EventStoreStreamSpanName      = "event.Store.Stream"
+	EventStoreAppendSpanName      = "event.Store.Append"
+	EventStoreAppendBatchSpanName = "event.Store.AppendBatch"
+	EventStoreStreamAllSpanName   = "event.Store.StreamAll"
+	EventStoreHeadSpanName        = "event.Store.Head"
 )
 
 // Metric names and descriptions exposed by the InstrumentedEventStore instrumentation.
@@ -35,21 +45,58 @@ const (
 
 	EventStoreAppendDurationMetricName        = "eventually.event_store.append.duration.milliseconds"
 	EventStoreAppendDurationMetricDescription = "Duration in milliseconds of event.Store.Append operations performed."
+
+	EventStoreAppendBatchDurationMetricName        = "eventually.event_store.append_batch.duration.milliseconds"
+	EventStoreAppendBatchDurationMetricDescription = "Duration in milliseconds of event.Store.AppendBatch operations performed."
+
+	EventStoreStreamAllDurationMetricName        = "eventually.event_store.stream_all.duration.milliseconds"
+	EventStoreStreamAllDurationMetricDescription = "Duration in milliseconds of event.Store.StreamAll operations performed."
+
+	EventStoreHeadDurationMetricName        = "eventually.event_store.head.duration.milliseconds"
+	EventStoreHeadDurationMetricDescription = "Duration in milliseconds of event.Store.Head operations performed."
 )
 
-var _ event.Store = new(InstrumentedEventStore)
+// Errors returned by InstrumentedEventStore when the wrapped event.Store
+// does not implement the optional interface of the method called.
+//
+// They all wrap errors.ErrUnsupported.
+var (
+	ErrBatchAppendNotSupported = fmt.Errorf("opentelemetry.InstrumentedEventStore: batch appends not supported, %w",
+		errors.ErrUnsupported)
+	ErrStreamAllNotSupported = fmt.Errorf("opentelemetry.InstrumentedEventStore: streaming the global log not supported, %w",
+		errors.ErrUnsupported)
+	ErrHeadNotSupported = fmt.Errorf("opentelemetry.InstrumentedEventStore: reading the head of the log not supported, %w",
+		errors.ErrUnsupported)
+)
+
+var (
+	_ event.Store         = new(InstrumentedEventStore)
+	_ event.BatchAppender = new(InstrumentedEventStore)
+	_ event.AllStreamer   = new(InstrumentedEventStore)
+	_ event.HeadReader    = new(InstrumentedEventStore)
+)
 
 // InstrumentedEventStore is a wrapper type over an event.Store
 // instance to provide instrumentation, in the form of metrics and traces
 // using OpenTelemetry.
 //
+// The optional event.BatchAppender, event.AllStreamer and event.HeadReader
+// interfaces are always implemented, and forwarded to the wrapped event.Store:
+// an error wrapping errors.ErrUnsupported is returned if it does not implement them.
+//
+// Spans report the MessageAttributes of the Message being handled, if carried
+// by the context (see message.ContextWithCause).
+//
 // Use NewInstrumentedEventStore for constructing a new instance of this type.
 type InstrumentedEventStore struct {
 	eventStore event.Store
 
-	tracer         trace.Tracer
-	streamDuration metric.Int64Histogram
-	appendDuration metric.Int64Histogram
+	tracer              trace.Tracer
+	streamDuration      metric.Int64Histogram
+	appendDuration      metric.Int64Histogram
+	appendBatchDuration metric.Int64Histogram
+	streamAllDuration   metric.Int64Histogram
+	headDuration        metric.Int64Histogram
 }
 
 func (ies *InstrumentedEventStore) registerMetrics(meter metric.Meter) error {
@@ -71,6 +118,30 @@ func (ies *InstrumentedEventStore) registerMetrics(meter metric.Meter) error {
 		return fmt.Errorf("opentelemetry.InstrumentedEventStore: failed to register metric, %w", err)
 	}
 
+	if ies.appendBatchDuration, err = meter.Int64Histogram(
+		EventStoreAppendBatchDurationMetricName,
+		metric.WithUnit(MetricUnitMilliseconds),
+		metric.WithDescription(EventStoreAppendBatchDurationMetricDescription),
+	); err != nil {
+		return fmt.Errorf("opentelemetry.InstrumentedEventStore: failed to register metric, %w", err)
+	}
+
+	if ies.streamAllDuration, err = meter.Int64Histogram(
+		EventStoreStreamAllDurationMetricName,
+		metric.WithUnit(MetricUnitMilliseconds),
+		metric.WithDescription(EventStoreStreamAllDurationMetricDescription),
+	); err != nil {
+		return fmt.Errorf("opentelemetry.InstrumentedEventStore: failed to register metric, %w", err)
+	}
+
+	if ies.headDuration, err = meter.Int64Histogram(
+		EventStoreHeadDurationMetricName,
+		metric.WithUnit(MetricUnitMilliseconds),
+		metric.WithDescription(EventStoreHeadDurationMetricDescription),
+	); err != nil {
+		return fmt.Errorf("opentelemetry.InstrumentedEventStore: failed to register metric, %w", err)
+	}
+
 	return nil
 }
 
@@ -82,10 +153,13 @@ func NewInstrumentedEventStore(eventStore event.Store, options ...Option) (*Inst
 	cfg := newConfig(options...)
 
 	ies := &InstrumentedEventStore{
-		eventStore:     eventStore,
-		tracer:         cfg.tracer(),
-		streamDuration: nil,
-		appendDuration: nil,
+		eventStore:          eventStore,
+		tracer:              cfg.tracer(),
+		streamDuration:      nil,
+		appendDuration:      nil,
+		appendBatchDuration: nil,
+		streamAllDuration:   nil,
+		headDuration:        nil,
 	}
 
 	if err := ies.registerMetrics(cfg.meter()); err != nil {
@@ -108,7 +182,10 @@ func (ies *InstrumentedEventStore) Stream(
 	attributes := []attribute.KeyValue{
 		EventStreamIDKey.String(string(id)),
 		EventStreamVersionSelectorKey.Int64(int64(selector.From)),
+		EventStreamVersionSelectToKey.Int64(int64(selector.To)),
+		EventStreamDirectionKey.String(selector.Direction.String()),
 	}
+	attributes = append(attributes, causeAttributes(ctx)...)
 
 	return event.NewStream(func(yield func(event.Persisted) bool) error {
 		ctx, span := ies.tracer.Start(ctx, EventStoreStreamSpanName, trace.WithAttributes(attributes...))
@@ -158,8 +235,10 @@ func (ies *InstrumentedEventStore) Append(
 	attributes := []attribute.KeyValue{
 		EventStreamIDKey.String(string(id)),
 		EventStreamExpectedVersionKey.Int64(expectedVersion),
+		EventStreamVersionCheckKey.String(versionCheckName(expected)),
 		EventStoreNumEventsKey.Int(len(events)),
 	}
+	attributes = append(attributes, causeAttributes(ctx)...)
 
 	ctx, span := ies.tracer.Start(ctx, EventStoreAppendSpanName, trace.WithAttributes(attributes...))
 	start := time.Now()
@@ -180,3 +259,146 @@ func (ies *InstrumentedEventStore) Append(
 
 	return ies.eventStore.Append(ctx, id, expected, events...)
 }
+
+// AppendBatch calls the AppendBatch method of the wrapped event.Store,
+// and records metrics and traces around it.
+//
+// ErrBatchAppendNotSupported is returned if the wrapped event.Store
+// does not implement the event.BatchAppender interface.
+func (ies *InstrumentedEventStore) AppendBatch(
+	ctx context.Context,
+	appends ...event.StreamAppend,
+) (newVersions []version.Version, err error) {
+	var numEvents int
+	for _, app := range appends {
+		numEvents += len(app.Events)
+	}
+
+	attributes := []attribute.KeyValue{
+		EventStoreNumAppendsKey.Int(len(appends)),
+		EventStoreNumEventsKey.Int(numEvents),
+	}
+	attributes = append(attributes, causeAttributes(ctx)...)
+
+	ctx, span := ies.tracer.Start(ctx, EventStoreAppendBatchSpanName, trace.WithAttributes(attributes...))
+	start := time.Now()
+
+	defer func() {
+		duration := time.Since(start)
+		ies.appendBatchDuration.Record(ctx, duration.Milliseconds(), metric.WithAttributes(
+			ErrorAttribute.Bool(err != nil),
+		))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}()
+
+	appender, ok := ies.eventStore.(event.BatchAppender)
+	if !ok {
+		return nil, ErrBatchAppendNotSupported
+	}
+
+	return appender.AppendBatch(ctx, appends...)
+}
+
+// StreamAll calls the StreamAll method of the wrapped event.Store,
+// and records metrics and traces around it.
+//
+// Like Stream, the recorded duration covers the full iteration of the returned Stream.
+// ErrStreamAllNotSupported is returned by the Stream if the wrapped event.Store
+// does not implement the event.AllStreamer interface.
+func (ies *InstrumentedEventStore) StreamAll(ctx context.Context, selector event.PositionSelector) *event.Stream {
+	attributes := []attribute.KeyValue{
+		EventStoreFromPositionKey.Int64(int64(selector.From)), //nolint:gosec // This should not overflow.
+	}
+	attributes = append(attributes, causeAttributes(ctx)...)
+
+	return event.NewStream(func(yield func(event.Persisted) bool) error {
+		ctx, span := ies.tracer.Start(ctx, EventStoreStreamAllSpanName, trace.WithAttributes(attributes...))
+		start := time.Now()
+
+		var producerErr error
+
+		defer func() {
+			ies.streamAllDuration.Record(ctx, time.Since(start).Milliseconds(), metric.WithAttributes(
+				ErrorAttribute.Bool(producerErr != nil),
+			))
+
+			if producerErr != nil {
+				span.RecordError(producerErr)
+				span.SetStatus(codes.Error, producerErr.Error())
+			}
+
+			span.End()
+		}()
+
+		streamer, ok := ies.eventStore.(event.AllStreamer)
+		if !ok {
+			producerErr = ErrStreamAllNotSupported
+
+			return producerErr
+		}
+
+		inner := streamer.StreamAll(ctx, selector)
+
+		for evt := range inner.Iter() {
+			if !yield(evt) {
+				return nil
+			}
+		}
+
+		producerErr = inner.Err()
+
+		return producerErr
+	})
+}
+
+// Head calls the Head method of the wrapped event.Store,
+// and records metrics and traces around it.
+//
+// ErrHeadNotSupported is returned if the wrapped event.Store
+// does not implement the event.HeadReader interface.
+func (ies *InstrumentedEventStore) Head(ctx context.Context) (head event.Position, err error) {
+	ctx, span := ies.tracer.Start(ctx, EventStoreHeadSpanName, trace.WithAttributes(causeAttributes(ctx)...))
+	start := time.Now()
+
+	defer func() {
+		duration := time.Since(start)
+		ies.headDuration.Record(ctx, duration.Milliseconds(), metric.WithAttributes(
+			ErrorAttribute.Bool(err != nil),
+		))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(EventStoreHeadKey.Int64(int64(head))) //nolint:gosec // This should not overflow.
+		}
+
+		span.End()
+	}()
+
+	reader, ok := ies.eventStore.(event.HeadReader)
+	if !ok {
+		return 0, ErrHeadNotSupported
+	}
+
+	return reader.Head(ctx)
+}
+
+func versionCheckName(check version.Check) string {
+	switch check.(type) {
+	case version.CheckExact:
+		return "exact"
+	case version.CheckNoStream:
+		return "no_stream"
+	case version.CheckStreamExists:
+		return "stream_exists"
+	default:
+		return "any"
+	}
+}
@@ -101,6 +101,31 @@ func TestStream_RecordsSpan(t *testing.T) {
 	assert.Equal(t, codes.Unset, span.Status().Code, "successful stream should not set error status")
 	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamIDKey.String(string(testStreamID)))
 	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamVersionSelectorKey.Int64(1))
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamVersionSelectToKey.Int64(0))
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamDirectionKey.String("forward"))
+}
+
+func TestStream_RecordsSelectorBoundsAndDirection(t *testing.T) {
+	h := newHarness(t)
+
+	inner := event.NewInMemoryStore()
+	appendEnvelopes(t, inner, 3)
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(inner, h.options()...)
+	require.NoError(t, err)
+
+	drainStream(t, ies.Stream(t.Context(), testStreamID, version.Selector{
+		From:      1,
+		To:        2,
+		Direction: version.Backward,
+	}))
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamVersionSelectToKey.Int64(2))
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStreamDirectionKey.String("backward"))
 }
 
 func TestStream_RecordsHistogram(t *testing.T) {
@@ -349,6 +374,24 @@ func TestAppend_RecordsSpan_CheckAny(t *testing.T) {
 	spans := h.endedSpans()
 	require.Len(t, spans, 1)
 	assert.Contains(t, spans[0].Attributes(), opentelemetry.EventStreamExpectedVersionKey.Int64(-1))
+	assert.Contains(t, spans[0].Attributes(), opentelemetry.EventStreamVersionCheckKey.String("any"))
+}
+
+func TestAppend_RecordsSpan_CheckNoStream(t *testing.T) {
+	h := newHarness(t)
+
+	inner := event.NewInMemoryStore()
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(inner, h.options()...)
+	require.NoError(t, err)
+
+	_, err = ies.Append(t.Context(), testStreamID, version.NoStream)
+	require.NoError(t, err)
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), opentelemetry.EventStreamExpectedVersionKey.Int64(-1))
+	assert.Contains(t, spans[0].Attributes(), opentelemetry.EventStreamVersionCheckKey.String("no_stream"))
 }
 
 func TestAppend_RecordsHistogram(t *testing.T) {
@@ -454,3 +497,125 @@ func TestAppend_SpanIsChildOfParent(t *testing.T) {
 	assert.Equal(t, "parent", parentSpan.Name())
 	assert.Equal(t, parentSpan.SpanContext().SpanID(), appendSpan.Parent().SpanID())
 }
+
+func TestAppendBatch_DelegatesAndRecordsSpan(t *testing.T) {
+	h := newHarness(t)
+
+	inner := event.NewInMemoryStore()
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(inner, h.options()...)
+	require.NoError(t, err)
+
+	newVersions, err := ies.AppendBatch(t.Context(),
+		event.StreamAppend{
+			StreamID: testStreamID,
+			Expected: version.NoStream,
+			Events:   []event.Envelope{{Message: noopMessage{id: 0}}, {Message: noopMessage{id: 1}}},
+		},
+		event.StreamAppend{
+			StreamID: "other-stream",
+			Expected: version.Any,
+			Events:   []event.Envelope{{Message: noopMessage{id: 2}}},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []version.Version{2, 1}, newVersions)
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, opentelemetry.EventStoreAppendBatchSpanName, span.Name())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStoreNumAppendsKey.Int(2))
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStoreNumEventsKey.Int(3))
+
+	sm := h.collectScopeMetrics(t)
+	findMetric(t, &sm, opentelemetry.EventStoreAppendBatchDurationMetricName)
+}
+
+func TestAppendBatch_UnsupportedStore_RecordsError(t *testing.T) {
+	h := newHarness(t)
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(&errorEventStore{}, h.options()...)
+	require.NoError(t, err)
+
+	_, err = ies.AppendBatch(t.Context(), event.StreamAppend{StreamID: testStreamID, Expected: version.Any, Events: nil})
+	require.ErrorIs(t, err, opentelemetry.ErrBatchAppendNotSupported)
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestStreamAll_DelegatesAndRecordsSpan(t *testing.T) {
+	h := newHarness(t)
+
+	inner := event.NewInMemoryStore()
+	appendEnvelopes(t, inner, 3)
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(inner, h.options()...)
+	require.NoError(t, err)
+
+	var positions []event.Position
+
+	stream := ies.StreamAll(t.Context(), event.PositionSelector{From: 2})
+	for evt := range stream.Iter() {
+		positions = append(positions, evt.Position)
+	}
+
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []event.Position{2, 3}, positions)
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, opentelemetry.EventStoreStreamAllSpanName, span.Name())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Contains(t, span.Attributes(), opentelemetry.EventStoreFromPositionKey.Int64(2))
+
+	sm := h.collectScopeMetrics(t)
+	findMetric(t, &sm, opentelemetry.EventStoreStreamAllDurationMetricName)
+}
+
+func TestHead_DelegatesAndRecordsSpan(t *testing.T) {
+	h := newHarness(t)
+
+	inner := event.NewInMemoryStore()
+	appendEnvelopes(t, inner, 3)
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(inner, h.options()...)
+	require.NoError(t, err)
+
+	head, err := ies.Head(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, event.Position(3), head)
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, opentelemetry.EventStoreHeadSpanName, spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), opentelemetry.EventStoreHeadKey.Int64(3))
+
+	sm := h.collectScopeMetrics(t)
+	findMetric(t, &sm, opentelemetry.EventStoreHeadDurationMetricName)
+}
+
+func TestGlobalLog_UnsupportedStore_ReturnsErrUnsupported(t *testing.T) {
+	h := newHarness(t)
+
+	ies, err := opentelemetry.NewInstrumentedEventStore(&errorEventStore{}, h.options()...)
+	require.NoError(t, err)
+
+	stream := ies.StreamAll(t.Context(), event.PositionSelector{From: 1})
+	for range stream.Iter() {
+		t.Fatalf("no events should be yielded when streaming the global log is not supported")
+	}
+
+	require.ErrorIs(t, stream.Err(), opentelemetry.ErrStreamAllNotSupported)
+	require.ErrorIs(t, stream.Err(), errors.ErrUnsupported)
+
+	_, err = ies.Head(t.Context())
+	require.ErrorIs(t, err, opentelemetry.ErrHeadNotSupported)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
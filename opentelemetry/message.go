@@ -0,0 +1,48 @@
+package opentelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/get-eventually/go-eventually/message"
+)
+
+// Attribute keys used to trace the flow of Messages, reporting the IDs
+// held by their message.Metadata.
+const (
+	MessageIDKey     attribute.Key = "message.id"
+	CorrelationIDKey attribute.Key = "message.correlation_id"
+	CausationIDKey   attribute.Key = "message.causation_id"
+)
+
+// MessageAttributes returns the attributes reporting the Message, Correlation
+// and Causation IDs held by the provided Metadata, skipping the missing ones.
+func MessageAttributes(metadata message.Metadata) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+
+	if id := metadata.MessageID(); id != "" {
+		attributes = append(attributes, MessageIDKey.String(id))
+	}
+
+	if id := metadata.CorrelationID(); id != "" {
+		attributes = append(attributes, CorrelationIDKey.String(id))
+	}
+
+	if id := metadata.CausationID(); id != "" {
+		attributes = append(attributes, CausationIDKey.String(id))
+	}
+
+	return attributes
+}
+
+// causeAttributes returns the MessageAttributes of the Message being handled,
+// carried by the context through message.ContextWithCause, if any.
+func causeAttributes(ctx context.Context) []attribute.KeyValue {
+	cause, ok := message.CauseFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return MessageAttributes(cause)
+}
@@ -40,6 +40,9 @@ const (
 // instance to provide instrumentation, in the form of metrics and traces
 // using OpenTelemetry.
 //
+// Spans report the MessageAttributes of the Message being handled, if carried
+// by the context (see message.ContextWithCause).
+//
 // Use NewInstrumentedRepository for constructing a new instance of this type.
 type InstrumentedRepository[I aggregate.ID, T aggregate.Root[I]] struct {
 	aggregateType aggregate.Type[I, T]
@@ -112,6 +115,7 @@ func (ir *InstrumentedRepository[I, T]) Get(ctx context.Context, id I) (result T
 	spanAttributes := append(attributes,
 		AggregateIDAttribute.String(id.String()),
 	)
+	spanAttributes = append(spanAttributes, causeAttributes(ctx)...)
 
 	ctx, span := ir.tracer.Start(ctx, RepositoryGetSpanName, trace.WithAttributes(spanAttributes...))
 	start := time.Now()
@@ -149,6 +153,7 @@ func (ir *InstrumentedRepository[I, T]) Save(ctx context.Context, root T) (err e
 		AggregateIDAttribute.String(root.AggregateID().String()),
 		AggregateVersionAttribute.Int64(int64(root.Version())),
 	)
+	spanAttributes = append(spanAttributes, causeAttributes(ctx)...)
 
 	ctx, span := ir.tracer.Start(ctx, RepositorySaveSpanName, trace.WithAttributes(spanAttributes...))
 	start := time.Now()
@@ -17,6 +17,7 @@ import (
 	"github.com/get-eventually/go-eventually/aggregate"
 	"github.com/get-eventually/go-eventually/event"
 	"github.com/get-eventually/go-eventually/internal/user"
+	"github.com/get-eventually/go-eventually/message"
 	otelex "github.com/get-eventually/go-eventually/opentelemetry"
 )
 
@@ -251,6 +252,28 @@ func TestSave_RecordsSpan(t *testing.T) {
 	assert.Contains(t, span.Attributes(), otelex.AggregateVersionAttribute.Int64(int64(usr.Version())))
 }
 
+func TestSave_RecordsSpan_WithCauseAttributes(t *testing.T) {
+	h := newHarness(t)
+
+	repo, err := otelex.NewInstrumentedRepository(user.Type, newUserRepository(), h.options()...)
+	require.NoError(t, err)
+
+	cause := message.Metadata{}.WithMessageID("command").WithCorrelationID("flow")
+	ctx := message.ContextWithCause(t.Context(), cause)
+
+	require.NoError(t, repo.Save(ctx, newTestUser(t, newUUID(t))))
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+
+	assert.Contains(t, spans[0].Attributes(), otelex.MessageIDKey.String("command"))
+	assert.Contains(t, spans[0].Attributes(), otelex.CorrelationIDKey.String("flow"))
+
+	for _, attr := range spans[0].Attributes() {
+		assert.NotEqual(t, otelex.CausationIDKey, attr.Key, "missing ids should not be reported")
+	}
+}
+
 func TestSave_RecordsHistogram(t *testing.T) {
 	h := newHarness(t)
 
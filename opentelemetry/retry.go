@@ -0,0 +1,63 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/get-eventually/go-eventually/command"
+)
+
+// Attribute keys used by the RetryNotify instrumentation.
+const (
+	CommandNameKey  attribute.Key = "command.name"
+	RetryAttemptKey attribute.Key = "command.retry.attempt"
+	RetryErrorKey   attribute.Key = "command.retry.error"
+)
+
+// Span event names emitted by the RetryNotify instrumentation.
+const (
+	CommandRetryEventName = "command.retry"
+)
+
+// Metric names and descriptions exposed by the RetryNotify instrumentation.
+const (
+	CommandRetriesMetricName        = "eventually.command.retries"
+	CommandRetriesMetricDescription = "Number of times a Command has been retried after a version conflict."
+)
+
+// NewRetryNotify returns a command.RetryNotifyFunc that exposes the attempts
+// performed by command.RetryOnConflict to OpenTelemetry.
+//
+// Every retry increments a counter, with the Command name as attribute,
+// and adds an event to the current span with the number of the failed attempt.
+//
+// An error is returned if metrics could not be registered.
+func NewRetryNotify(options ...Option) (command.RetryNotifyFunc, error) {
+	cfg := newConfig(options...)
+
+	retries, err := cfg.meter().Int64Counter(
+		CommandRetriesMetricName,
+		metric.WithDescription(CommandRetriesMetricDescription),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("opentelemetry.NewRetryNotify: failed to register metric, %w", err)
+	}
+
+	return func(ctx context.Context, cmd command.GenericEnvelope, attempt int, err error) {
+		name := CommandNameKey.String(cmd.Message.Name())
+
+		retries.Add(ctx, 1, metric.WithAttributes(name))
+
+		attributes := append([]attribute.KeyValue{
+			name,
+			RetryAttemptKey.Int(attempt),
+			RetryErrorKey.String(err.Error()),
+		}, MessageAttributes(cmd.Metadata)...)
+
+		trace.SpanFromContext(ctx).AddEvent(CommandRetryEventName, trace.WithAttributes(attributes...))
+	}, nil
+}
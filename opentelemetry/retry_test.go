@@ -0,0 +1,67 @@
+package opentelemetry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/get-eventually/go-eventually/command"
+	otelex "github.com/get-eventually/go-eventually/opentelemetry"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+type retryTestCommand struct{}
+
+func (retryTestCommand) Name() string { return "RetryTestCommand" }
+
+func TestNewRetryNotify_RecordsRetries(t *testing.T) {
+	h := newHarness(t)
+
+	notify, err := otelex.NewRetryNotify(h.options()...)
+	require.NoError(t, err)
+
+	var attempts int
+
+	handler := command.Decorate(command.HandlerFunc[retryTestCommand](
+		func(context.Context, command.Envelope[retryTestCommand]) error {
+			if attempts++; attempts < 3 {
+				return version.ConflictError{Expected: 1, Actual: 2}
+			}
+
+			return nil
+		},
+	), command.RetryOnConflict(
+		command.WithRetryBackoff(time.Millisecond, time.Millisecond),
+		command.WithRetryNotify(notify),
+	))
+
+	ctx, span := h.tracer.Tracer("test").Start(t.Context(), "handle")
+	require.NoError(t, handler.Handle(ctx, command.ToEnvelope(retryTestCommand{})))
+	span.End()
+
+	spans := h.endedSpans()
+	require.Len(t, spans, 1)
+
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+
+	for i, evt := range events {
+		assert.Equal(t, otelex.CommandRetryEventName, evt.Name)
+		assert.Contains(t, evt.Attributes, otelex.RetryAttemptKey.Int(i+1))
+		assert.Contains(t, evt.Attributes, otelex.CommandNameKey.String("RetryTestCommand"))
+	}
+
+	sm := h.collectScopeMetrics(t)
+	m := findMetric(t, &sm, otelex.CommandRetriesMetricName)
+
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+	assert.Equal(t, attribute.NewSet(otelex.CommandNameKey.String("RetryTestCommand")), sum.DataPoints[0].Attributes)
+}
@@ -26,6 +26,9 @@ import (
 // to both "events" and "event_streams" to append the Domain events
 // recorded by Aggregate Roots. These updates are performed within the same transaction.
 //
+// Optionally, the Domain Events can also be written to an outbox table
+// in the same transaction, using WithOutbox.
+//
 // Note: the tables the Repository points to can be changed using the
 // available functional options.
 type AggregateRepository[ID aggregate.ID, T aggregate.Root[ID]] struct {
@@ -37,6 +40,9 @@ type AggregateRepository[ID aggregate.ID, T aggregate.Root[ID]] struct {
 	aggregateTableName string
 	eventsTableName    string
 	streamsTableName   string
+	outboxTableName    string
+
+	upcasters *event.UpcasterRegistry
 }
 
 // NewAggregateRepository returns a new AggregateRepository instance.
@@ -55,6 +61,8 @@ func NewAggregateRepository[ID aggregate.ID, T aggregate.Root[ID]](
 		aggregateTableName: DefaultAggregateTableName,
 		eventsTableName:    DefaultEventsTableName,
 		streamsTableName:   DefaultStreamsTableName,
+		outboxTableName:    "",
+		upcasters:          nil,
 	}
 
 	for _, opt := range options {
@@ -115,21 +123,28 @@ func (repo AggregateRepository[ID, T]) get(ctx context.Context, tx queryRower, i
 }
 
 // Save saves the new state of the provided aggregate.Root instance.
+//
+// Serialization failures caused by concurrent writes are returned
+// as a version.ConflictError, so that they can be retried.
 func (repo AggregateRepository[ID, T]) Save(ctx context.Context, root T) (err error) {
 	txOpts := pgx.TxOptions{ //nolint:exhaustruct // We don't need all fields.
 		IsoLevel:   pgx.Serializable,
 		AccessMode: pgx.ReadWrite,
 	}
 
-	return internal.RunTransaction(ctx, repo.conn, txOpts, func(ctx context.Context, tx pgx.Tx) error {
+	var expectedRootVersion version.Version
+
+	err = internal.RunTransaction(ctx, repo.conn, txOpts, func(ctx context.Context, tx pgx.Tx) error {
 		eventsToCommit := root.FlushRecordedEvents()
-		expectedRootVersion := root.Version() - version.Version(len(eventsToCommit)) //nolint:gosec // This should not overflow.
+		event.StampCause(ctx, eventsToCommit)
+
+		expectedRootVersion = root.Version() - version.Version(len(eventsToCommit)) //nolint:gosec // This should not overflow.
 		eventStreamID := event.StreamID(root.AggregateID().String())
 
 		newEventStreamVersion, err := appendDomainEvents(
 			ctx, tx,
 			repo.eventsTableName, repo.streamsTableName,
-			repo.messageSerde,
+			repo.messageSerde, repo.upcasters,
 			eventStreamID,
 			version.CheckExact(expectedRootVersion),
 			eventsToCommit...,
@@ -145,8 +160,20 @@ func (repo AggregateRepository[ID, T]) Save(ctx context.Context, root T) (err er
 			})
 		}
 
+		if repo.outboxTableName != "" {
+			if err := appendToOutbox(
+				ctx, tx,
+				repo.outboxTableName, repo.eventsTableName,
+				eventStreamID, expectedRootVersion,
+			); err != nil {
+				return repo.saveErr("failed to write to outbox, %w", err)
+			}
+		}
+
 		return repo.saveAggregateState(ctx, tx, eventStreamID, root)
 	})
+
+	return conflictOnSerializationFailure(err, version.CheckExact(expectedRootVersion))
 }
 
 const saveAggregateQueryTemplate = `
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/get-eventually/go-eventually/event"
 	"github.com/get-eventually/go-eventually/message"
@@ -29,6 +31,17 @@ const (
 		ON CONFLICT (event_stream_id) DO
 		UPDATE SET version = $2
 	`
+
+	committedDomainEventsQueryTemplate = `
+		SELECT event_stream_id, "version", global_position, event_id
+		FROM %s
+		WHERE event_id = ANY($1)
+		ORDER BY global_position
+	`
+
+	lockGlobalPositionQuery = `SELECT pg_advisory_xact_lock(hashtext($1))`
+
+	notifyQuery = `SELECT pg_notify($1, $2)`
 )
 
 func appendDomainEvents(
@@ -36,10 +49,29 @@ func appendDomainEvents(
 	tx pgx.Tx,
 	eventsTableName, streamsTableName string,
 	messageSerializer serde.Serializer[message.Message, []byte],
+	upcasters *event.UpcasterRegistry,
 	id event.StreamID,
 	expected version.Check,
 	events ...event.Envelope,
 ) (version.Version, error) {
+	committed, err := committedDomainEvents(ctx, tx, eventsTableName, events)
+	if err != nil {
+		return 0, err
+	}
+
+	previousVersion, retried, err := event.MatchRetriedAppend(id, events, committed)
+	if err != nil {
+		return 0, fmt.Errorf("postgres.appendDomainEvents: failed to append domain events, %w", err)
+	}
+
+	if retried {
+		if err := expected.Verify(previousVersion); err != nil {
+			return 0, fmt.Errorf("postgres.appendDomainEvents: failed to append domain events, %w", event.ErrDuplicateEventID)
+		}
+
+		return previousVersion + version.Version(len(events)), nil //nolint:gosec // This should not overflow.
+	}
+
 	row := tx.QueryRow(
 		ctx,
 		fmt.Sprintf(getEventStreamQueryTemplate, streamsTableName),
@@ -51,14 +83,8 @@ func appendDomainEvents(
 		return 0, fmt.Errorf("postgres.appendDomainEvents: failed to scan old event stream version, %w", err)
 	}
 
-	if v, ok := expected.(version.CheckExact); ok && oldVersion != version.Version(v) {
-		return 0, fmt.Errorf(
-			"postgres.appendDomainEvents: event stream version check failed, %w",
-			version.ConflictError{
-				Expected: version.Version(v),
-				Actual:   oldVersion,
-			},
-		)
+	if err := expected.Verify(oldVersion); err != nil {
+		return 0, fmt.Errorf("postgres.appendDomainEvents: event stream version check failed, %w", err)
 	}
 
 	newVersion := oldVersion + version.Version(len(events)) //nolint:gosec // This should not overflow.
@@ -71,24 +97,81 @@ func appendDomainEvents(
 		return 0, fmt.Errorf("postgres.EventStore: failed to update event stream, %w", err)
 	}
 
+	// NOTE: global positions are assigned by an identity column, whose values
+	// are not guaranteed to be committed in order by concurrent transactions.
+	// Serializing the appends on the events table makes sure that readers of the
+	// global log never observe a lower position after a higher one has been committed.
+	if _, err := tx.Exec(ctx, lockGlobalPositionQuery, eventsTableName); err != nil {
+		return 0, fmt.Errorf("postgres.appendDomainEvents: failed to acquire global position lock, %w", err)
+	}
+
 	for i, event := range events {
 		eventVersion := oldVersion + version.Version(i) + 1
 
 		if err := appendDomainEvent(
 			ctx, tx,
-			eventsTableName, messageSerializer,
+			eventsTableName, messageSerializer, upcasters,
 			id, eventVersion, newVersion, event,
 		); err != nil {
 			return 0, err
 		}
 	}
 
+	// NOTE: notifications are delivered only when the transaction commits,
+	// using the events table name as channel. See EventStore.TailAll.
+	if _, err := tx.Exec(ctx, notifyQuery, eventsTableName, string(id)); err != nil {
+		return 0, fmt.Errorf("postgres.appendDomainEvents: failed to notify new domain events, %w", err)
+	}
+
 	return newVersion, nil
 }
 
+// committedDomainEvents returns the Domain Events already committed with the same IDs
+// of the specified ones, in global position order, to detect retried appends.
+func committedDomainEvents(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventsTableName string,
+	events []event.Envelope,
+) ([]event.Persisted, error) {
+	var ids []string
+
+	for _, evt := range events {
+		if id := evt.ID(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(committedDomainEventsQueryTemplate, eventsTableName), ids)
+	if err != nil {
+		return nil, fmt.Errorf("postgres.committedDomainEvents: failed to query committed domain events, %w", err)
+	}
+
+	committed, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (event.Persisted, error) {
+		var (
+			evt     event.Persisted
+			eventID string
+		)
+
+		err := row.Scan(&evt.StreamID, &evt.Version, &evt.Position, &eventID)
+		evt.Metadata = message.Metadata{}.WithMessageID(eventID)
+
+		return evt, err //nolint:wrapcheck // Wrapped below.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres.committedDomainEvents: failed to scan committed domain events, %w", err)
+	}
+
+	return committed, nil
+}
+
 const appendDomainEventQueryTemplate = `
-	INSERT INTO %s (event_stream_id, "type", "version", event, metadata)
-	VALUES ($1, $2, $3, $4, $5)
+	INSERT INTO %s (event_stream_id, "type", "version", event, metadata, event_id)
+	VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 `
 
 func appendDomainEvent(
@@ -96,6 +179,7 @@ func appendDomainEvent(
 	tx pgx.Tx,
 	eventsTableName string,
 	messageSerializer serde.Serializer[message.Message, []byte],
+	upcasters *event.UpcasterRegistry,
 	id event.StreamID,
 	eventVersion, newVersion version.Version,
 	evt event.Envelope,
@@ -107,8 +191,13 @@ func appendDomainEvent(
 		return fmt.Errorf("postgres.appendDomainEvent: failed to serialize domain event, %w", err)
 	}
 
-	enrichedMetadata := evt.Metadata.
-		With("Recorded-At", time.Now().Format(time.RFC3339Nano)).
+	enrichedMetadata := evt.Metadata
+	if upcasters != nil {
+		enrichedMetadata = upcasters.WithSchemaVersion(msg.Name(), enrichedMetadata)
+	}
+
+	enrichedMetadata = enrichedMetadata.
+		With(event.RecordedAtMetadataKey, time.Now().Format(time.RFC3339Nano)).
 		With("Recorded-With-New-Overall-Version", strconv.Itoa(int(newVersion)))
 
 	metadata, err := serializeMetadata(enrichedMetadata)
@@ -119,8 +208,12 @@ func appendDomainEvent(
 	if _, err = tx.Exec(
 		ctx,
 		fmt.Sprintf(appendDomainEventQueryTemplate, eventsTableName),
-		id, msg.Name(), eventVersion, data, metadata,
-	); err != nil {
+		id, msg.Name(), eventVersion, data, metadata, evt.ID(),
+	); isUniqueViolation(err, eventIDIndexName) {
+		return fmt.Errorf("postgres.appendDomainEvent: failed to append new domain event to event store, %w", errors.Join(
+			event.ErrDuplicateEventID, err,
+		))
+	} else if err != nil {
 		return fmt.Errorf("postgres.appendDomainEvent: failed to append new domain event to event store, %w", err)
 	}
 
@@ -139,3 +232,43 @@ func serializeMetadata(metadata message.Metadata) ([]byte, error) {
 
 	return data, nil
 }
+
+// uniqueViolationCode is the PostgreSQL error code of unique constraint violations.
+const uniqueViolationCode = "23505"
+
+// serializationFailureCode is the PostgreSQL error code of serialization failures,
+// raised when concurrent Serializable transactions conflict with each other.
+const serializationFailureCode = "40001"
+
+// conflictOnSerializationFailure maps serialization failures to a version.ConflictError,
+// as they are caused by concurrent writes: this allows to retry them
+// like any other optimistic concurrency conflict, e.g. using command.RetryOnConflict.
+//
+// The actual version of the Event Stream is unknown, and left to zero.
+func conflictOnSerializationFailure(err error, expected version.Check) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != serializationFailureCode {
+		return err
+	}
+
+	conflictErr := version.ConflictError{Expected: 0, Actual: 0}
+	if v, ok := expected.(version.CheckExact); ok {
+		conflictErr.Expected = version.Version(v)
+	}
+
+	return errors.Join(err, conflictErr)
+}
+
+// eventIDIndexName is the name of the unique index on the events table
+// over the Domain Event IDs, without the table prefix set in MigrationsConfig.
+const eventIDIndexName = "events_event_id_idx"
+
+// isUniqueViolation returns true if the error is a unique constraint violation
+// of the specified index, regardless of its table prefix.
+func isUniqueViolation(err error, indexName string) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == uniqueViolationCode &&
+		strings.HasSuffix(pgErr.ConstraintName, indexName)
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/subscription"
+)
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ subscription.Checkpointer = Checkpointer{}
+
+// Checkpointer is a subscription.Checkpointer implementation targeted
+// to PostgreSQL databases.
+//
+// The implementation uses the "checkpoints" table as its operational table,
+// unless a different one is specified with WithCheckpointsTableName.
+//
+// Checkpoints can also be written using an existing transaction through
+// WriteInTx, which allows projections to commit their own writes and
+// the processed position atomically, achieving exactly-once processing.
+type Checkpointer struct {
+	conn      *pgxpool.Pool
+	tableName string
+}
+
+// NewCheckpointer returns a new Checkpointer instance.
+func NewCheckpointer(conn *pgxpool.Pool, options ...Option[*Checkpointer]) Checkpointer {
+	checkpointer := Checkpointer{
+		conn:      conn,
+		tableName: DefaultCheckpointsTableName,
+	}
+
+	for _, opt := range options {
+		opt.apply(&checkpointer)
+	}
+
+	return checkpointer
+}
+
+const (
+	readCheckpointQueryTemplate = `
+		SELECT "position"
+		FROM %s
+		WHERE subscription_name = $1
+	`
+
+	writeCheckpointQueryTemplate = `
+		INSERT INTO %s (subscription_name, "position")
+		VALUES ($1, $2)
+		ON CONFLICT (subscription_name) DO
+		UPDATE SET "position" = $2, updated_at = NOW()
+	`
+)
+
+// Read implements the subscription.Checkpointer interface.
+func (c Checkpointer) Read(ctx context.Context, name string) (event.Position, error) {
+	row := c.conn.QueryRow(ctx, fmt.Sprintf(readCheckpointQueryTemplate, c.tableName), name)
+
+	var position event.Position
+	if err := row.Scan(&position); errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("postgres.Checkpointer: failed to read checkpoint, %w", err)
+	}
+
+	return position, nil
+}
+
+// Write implements the subscription.Checkpointer interface.
+func (c Checkpointer) Write(ctx context.Context, name string, position event.Position) error {
+	return c.write(ctx, c.conn, name, position)
+}
+
+// WriteInTx writes the checkpoint for the named subscription using
+// the provided transaction.
+//
+// Use this method in an event.Processor to commit the checkpoint together
+// with the projection writes performed in the same transaction.
+func (c Checkpointer) WriteInTx(ctx context.Context, tx pgx.Tx, name string, position event.Position) error {
+	return c.write(ctx, tx, name, position)
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func (c Checkpointer) write(ctx context.Context, db execer, name string, position event.Position) error {
+	if _, err := db.Exec(
+		ctx,
+		fmt.Sprintf(writeCheckpointQueryTemplate, c.tableName),
+		name, position,
+	); err != nil {
+		return fmt.Errorf("postgres.Checkpointer: failed to write checkpoint, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
+	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/subscription"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestCheckpointer(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	checkpointer := postgres.NewCheckpointer(conn)
+
+	t.Run("read returns the zero position when no checkpoint exists", func(t *testing.T) {
+		position, err := checkpointer.Read(ctx, "missing")
+		require.NoError(t, err)
+		assert.Zero(t, position)
+	})
+
+	t.Run("written checkpoints can be read back", func(t *testing.T) {
+		require.NoError(t, checkpointer.Write(ctx, "written", 10))
+		require.NoError(t, checkpointer.Write(ctx, "written", 20))
+
+		position, err := checkpointer.Read(ctx, "written")
+		require.NoError(t, err)
+		assert.Equal(t, event.Position(20), position)
+	})
+
+	t.Run("checkpoints written in a transaction follow the transaction outcome", func(t *testing.T) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, checkpointer.WriteInTx(ctx, tx, "transactional", 5))
+		require.NoError(t, tx.Rollback(ctx))
+
+		position, err := checkpointer.Read(ctx, "transactional")
+		require.NoError(t, err)
+		assert.Zero(t, position)
+
+		require.NoError(t, pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			return checkpointer.WriteInTx(ctx, tx, "transactional", 5)
+		}))
+
+		position, err = checkpointer.Read(ctx, "transactional")
+		require.NoError(t, err)
+		assert.Equal(t, event.Position(5), position)
+	})
+
+	t.Run("catch-up subscriptions process events from the event store", func(t *testing.T) {
+		eventStore := postgres.NewEventStore(conn, serde.Chain(
+			user.EventProtoSerde,
+			serde.NewProtoJSON(func() *userv1.Event { return new(userv1.Event) }),
+		))
+
+		id := uuid.New()
+		streamID := event.StreamID(id.String())
+
+		usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, usr.UpdateEmail("john.doe@mail.com", time.Now(), nil))
+
+		_, err = eventStore.Append(ctx, streamID, version.Any, usr.FlushRecordedEvents()...)
+		require.NoError(t, err)
+
+		var (
+			mx       sync.Mutex
+			versions []version.Version
+		)
+
+		processor := event.ProcessorFunc(func(_ context.Context, evt event.Persisted) error {
+			mx.Lock()
+			defer mx.Unlock()
+
+			if evt.StreamID == streamID {
+				versions = append(versions, evt.Version)
+			}
+
+			return nil
+		})
+
+		sub := subscription.NewCatchUp(
+			"postgres-subscription", eventStore, checkpointer, processor,
+			subscription.WithPollInterval(10*time.Millisecond),
+		)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errs := make(chan error, 1)
+
+		go func() { errs <- sub.Run(ctx) }()
+
+		require.Eventually(t, func() bool {
+			mx.Lock()
+			defer mx.Unlock()
+
+			return len(versions) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+		assert.Equal(t, []version.Version{1, 2}, versions)
+
+		position, err := checkpointer.Read(context.Background(), "postgres-subscription")
+		require.NoError(t, err)
+		assert.NotZero(t, position)
+	})
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+)
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ command.DeduplicationStore = DeduplicationStore{}
+
+// DeduplicationStore is a command.DeduplicationStore implementation targeted
+// to PostgreSQL databases.
+//
+// The implementation uses the "command_outcomes" table as its operational table,
+// unless a different one is specified with WithCommandOutcomesTableName.
+//
+// Expired Outcomes are ignored, and can be removed using DeleteExpired.
+//
+// Outcomes are read and recorded in the transaction carried by the context,
+// if any: use a UnitOfWork to record them atomically with the changes
+// performed by the Command.
+type DeduplicationStore struct {
+	conn      *pgxpool.Pool
+	tableName string
+}
+
+// NewDeduplicationStore returns a new DeduplicationStore instance.
+func NewDeduplicationStore(conn *pgxpool.Pool, options ...Option[*DeduplicationStore]) DeduplicationStore {
+	store := DeduplicationStore{
+		conn:      conn,
+		tableName: DefaultCommandOutcomesTableName,
+	}
+
+	for _, opt := range options {
+		opt.apply(&store)
+	}
+
+	return store
+}
+
+const (
+	getOutcomeQueryTemplate = `
+		SELECT "error", error_code
+		FROM %s
+		WHERE command_id = $1 AND expires_at > NOW()
+	`
+
+	recordOutcomeQueryTemplate = `
+		INSERT INTO %s (command_id, "error", error_code, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::INTERVAL)
+		ON CONFLICT (command_id) DO
+		UPDATE SET "error" = $2, error_code = $3, recorded_at = NOW(), expires_at = NOW() + $4::INTERVAL
+	`
+
+	deleteExpiredOutcomesQueryTemplate = `
+		DELETE FROM %s
+		WHERE expires_at <= NOW()
+	`
+)
+
+type queryExecer interface {
+	queryRower
+	execer
+}
+
+// db returns the transaction carried by the context, if any,
+// or the connection pool otherwise.
+func (s DeduplicationStore) db(ctx context.Context) queryExecer {
+	if tx, ok := internal.TxFromContext(ctx); ok {
+		return tx
+	}
+
+	return s.conn
+}
+
+// Outcome implements the command.DeduplicationStore interface.
+func (s DeduplicationStore) Outcome(ctx context.Context, commandID string) (command.Outcome, error) {
+	row := s.db(ctx).QueryRow(ctx, fmt.Sprintf(getOutcomeQueryTemplate, s.tableName), commandID)
+
+	var outcome command.Outcome
+	if err := row.Scan(&outcome.Error, &outcome.Code); errors.Is(err, pgx.ErrNoRows) {
+		return outcome, command.ErrOutcomeNotFound
+	} else if err != nil {
+		return outcome, fmt.Errorf("postgres.DeduplicationStore: failed to fetch outcome, %w", err)
+	}
+
+	return outcome, nil
+}
+
+// RecordOutcome implements the command.DeduplicationStore interface.
+func (s DeduplicationStore) RecordOutcome(
+	ctx context.Context,
+	commandID string,
+	outcome command.Outcome,
+	ttl time.Duration,
+) error {
+	query := fmt.Sprintf(recordOutcomeQueryTemplate, s.tableName)
+
+	if _, err := s.db(ctx).Exec(ctx, query, commandID, outcome.Error, outcome.Code, ttl); err != nil {
+		return fmt.Errorf("postgres.DeduplicationStore: failed to record outcome, %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes all the expired Outcomes from the table,
+// returning the number of Outcomes removed.
+func (s DeduplicationStore) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := s.conn.Exec(ctx, fmt.Sprintf(deleteExpiredOutcomesQueryTemplate, s.tableName))
+	if err != nil {
+		return 0, fmt.Errorf("postgres.DeduplicationStore: failed to delete expired outcomes, %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
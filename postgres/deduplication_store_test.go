@@ -0,0 +1,96 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+)
+
+func TestDeduplicationStore(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	store := postgres.NewDeduplicationStore(conn)
+
+	t.Run("outcome returns an error when no outcome has been recorded", func(t *testing.T) {
+		_, err := store.Outcome(ctx, "missing")
+		assert.ErrorIs(t, err, command.ErrOutcomeNotFound)
+	})
+
+	t.Run("recorded outcomes are returned until they expire", func(t *testing.T) {
+		failure := command.NewOutcome(errors.New("failed"))
+		failure.Code = "failed"
+		require.NoError(t, store.RecordOutcome(ctx, "command", failure, time.Hour))
+
+		outcome, err := store.Outcome(ctx, "command")
+		require.NoError(t, err)
+		assert.Equal(t, failure, outcome)
+
+		require.NoError(t, store.RecordOutcome(ctx, "expired", command.NewOutcome(nil), time.Millisecond))
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = store.Outcome(ctx, "expired")
+		require.ErrorIs(t, err, command.ErrOutcomeNotFound)
+
+		deleted, err := store.DeleteExpired(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+
+	t.Run("outcomes are recorded in the transaction of a unit of work", func(t *testing.T) {
+		unitOfWork := postgres.NewUnitOfWork(conn)
+		errAborted := errors.New("aborted")
+
+		err := unitOfWork.Run(ctx, func(ctx context.Context) error {
+			if err := store.RecordOutcome(ctx, "rolled-back", command.NewOutcome(nil), time.Hour); err != nil {
+				return err
+			}
+
+			if _, err := store.Outcome(ctx, "rolled-back"); err != nil {
+				return err
+			}
+
+			return errAborted
+		})
+		require.ErrorIs(t, err, errAborted)
+
+		_, err = store.Outcome(ctx, "rolled-back")
+		require.ErrorIs(t, err, command.ErrOutcomeNotFound)
+
+		require.NoError(t, unitOfWork.Run(ctx, func(ctx context.Context) error {
+			return store.RecordOutcome(ctx, "committed", command.NewOutcome(nil), time.Hour)
+		}))
+
+		_, err = store.Outcome(ctx, "committed")
+		require.NoError(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,26 +16,75 @@ import (
 	"github.com/get-eventually/go-eventually/version"
 )
 
-//nolint:exhaustruct // Interface implementation assertion.
-var _ event.Store = EventStore{}
+//nolint:exhaustruct // Interface implementation assertions.
+var (
+	_ event.Store         = EventStore{}
+	_ event.AllStreamer   = EventStore{}
+	_ event.BatchAppender = EventStore{}
+	_ event.HeadReader    = EventStore{}
+)
 
 // EventStore is an event.Store implementation targeted to PostgreSQL databases.
 //
 // The implementation uses "event_streams" and "events" as their
-// operational tables. Updates to these tables are transactional.
+// operational tables by default: use WithEventStoreStreamsTableName and
+// WithEventStoreEventsTableName to point to different ones.
+// Updates to these tables are transactional.
+//
+// The name of each Domain Event type is stored alongside its payload, and passed
+// to messageSerde on deserialization if it implements serde.NamedDeserializer:
+// this allows to store plain payloads, e.g. using serde.PlainRegistry.
+//
+// Use WithEventStoreUpcasters to upcast the serialized Domain Events
+// to their current schema version before they get deserialized.
+//
+// Committed appends are also notified through PostgreSQL LISTEN/NOTIFY,
+// which allows to tail the global log with low latency using TailAll.
 type EventStore struct {
-	conn         *pgxpool.Pool
-	messageSerde serde.Bytes[message.Message]
+	conn             *pgxpool.Pool
+	messageSerde     serde.Bytes[message.Message]
+	eventsTableName  string
+	streamsTableName string
+	tailPollInterval time.Duration
+	upcasters        *event.UpcasterRegistry
 }
 
 // NewEventStore returns a new EventStore instance.
-func NewEventStore(conn *pgxpool.Pool, messageSerde serde.Bytes[message.Message]) EventStore {
-	return EventStore{
-		conn:         conn,
-		messageSerde: messageSerde,
+func NewEventStore(
+	conn *pgxpool.Pool,
+	messageSerde serde.Bytes[message.Message],
+	options ...Option[*EventStore],
+) EventStore {
+	es := EventStore{
+		conn:             conn,
+		messageSerde:     messageSerde,
+		eventsTableName:  DefaultEventsTableName,
+		streamsTableName: DefaultStreamsTableName,
+		tailPollInterval: DefaultTailPollInterval,
+		upcasters:        nil,
 	}
+
+	for _, opt := range options {
+		opt.apply(&es)
+	}
+
+	return es
 }
 
+const (
+	streamQueryTemplate = `
+	SELECT event_stream_id, "type", version, global_position, event, metadata FROM %s
+	WHERE event_stream_id = $1 AND version >= $2 AND ($3 = 0 OR version <= $3)
+	ORDER BY version %s`
+
+	streamAllQueryTemplate = `
+	SELECT event_stream_id, "type", version, global_position, event, metadata FROM %s
+	WHERE global_position >= $1
+	ORDER BY global_position`
+
+	headQueryTemplate = `SELECT COALESCE(MAX(global_position), 0) FROM %s`
+)
+
 // Stream implements the event.Streamer interface.
 func (es EventStore) Stream(
 	ctx context.Context,
@@ -42,60 +92,117 @@ func (es EventStore) Stream(
 	selector version.Selector,
 ) *event.Stream {
 	return event.NewStream(func(yield func(event.Persisted) bool) error {
-		rows, err := es.conn.Query(
-			ctx,
-			`SELECT version, event, metadata FROM events
-			WHERE event_stream_id = $1 AND version >= $2
-			ORDER BY version`,
-			id, selector.From,
+		return es.streamRows(
+			ctx, yield,
+			fmt.Sprintf(streamQueryTemplate, es.eventsTableName, sortOrder(selector.Direction)),
+			id, selector.From, selector.To,
 		)
-		if err != nil {
-			return fmt.Errorf("postgres.EventStore: failed to query events table, %w", err)
+	})
+}
+
+// StreamAll implements the event.AllStreamer interface.
+func (es EventStore) StreamAll(ctx context.Context, selector event.PositionSelector) *event.Stream {
+	return event.NewStream(func(yield func(event.Persisted) bool) error {
+		return es.streamRows(
+			ctx, yield,
+			fmt.Sprintf(streamAllQueryTemplate, es.eventsTableName),
+			selector.From,
+		)
+	})
+}
+
+// Head implements the event.HeadReader interface.
+func (es EventStore) Head(ctx context.Context) (event.Position, error) {
+	var head event.Position
+
+	if err := es.conn.QueryRow(ctx, fmt.Sprintf(headQueryTemplate, es.eventsTableName)).Scan(&head); err != nil {
+		return 0, fmt.Errorf("postgres.EventStore: failed to read head position, %w", err)
+	}
+
+	return head, nil
+}
+
+func sortOrder(direction version.Direction) string {
+	if direction == version.Backward {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+func (es EventStore) streamRows(
+	ctx context.Context,
+	yield func(event.Persisted) bool,
+	query string,
+	args ...any,
+) error {
+	rows, err := es.conn.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("postgres.EventStore: failed to query events table, %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("postgres.EventStore: context error, %w", err)
 		}
-		defer rows.Close()
 
-		for rows.Next() {
-			var (
-				rawEvent     []byte
-				rawMetadata  json.RawMessage
-				eventVersion version.Version
-			)
+		var (
+			streamID       event.StreamID
+			eventType      string
+			eventVersion   version.Version
+			globalPosition event.Position
+			rawEvent       []byte
+			rawMetadata    json.RawMessage
+		)
 
-			if err := rows.Scan(&eventVersion, &rawEvent, &rawMetadata); err != nil {
-				return fmt.Errorf("postgres.EventStore: failed to scan next row, %w", err)
-			}
+		if err := rows.Scan(
+			&streamID, &eventType, &eventVersion, &globalPosition, &rawEvent, &rawMetadata,
+		); err != nil {
+			return fmt.Errorf("postgres.EventStore: failed to scan next row, %w", err)
+		}
 
-			msg, err := es.messageSerde.Deserialize(rawEvent)
-			if err != nil {
-				return fmt.Errorf("postgres.EventStore: failed to deserialize event, %w", err)
-			}
+		var metadata message.Metadata
+		if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
+			return fmt.Errorf("postgres.EventStore: failed to deserialize metadata, %w", err)
+		}
 
-			var metadata message.Metadata
-			if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
-				return fmt.Errorf("postgres.EventStore: failed to deserialize metadata, %w", err)
+		if es.upcasters != nil {
+			var err error
+			if rawEvent, metadata, err = es.upcasters.Upcast(eventType, metadata, rawEvent); err != nil {
+				return fmt.Errorf("postgres.EventStore: failed to upcast event, %w", err)
 			}
+		}
 
-			if !yield(event.Persisted{
-				StreamID: id,
-				Version:  eventVersion,
-				Envelope: event.Envelope{
-					Message:  msg,
-					Metadata: metadata,
-				},
-			}) {
-				return nil
-			}
+		msg, err := serde.DeserializeNamed(es.messageSerde, eventType, rawEvent)
+		if err != nil {
+			return fmt.Errorf("postgres.EventStore: failed to deserialize event, %w", err)
 		}
 
-		if err := rows.Err(); err != nil {
-			return fmt.Errorf("postgres.EventStore: failed to iterate events rows, %w", err)
+		if !yield(event.Persisted{
+			StreamID: streamID,
+			Version:  eventVersion,
+			Position: globalPosition,
+			Envelope: event.Envelope{
+				Message:  msg,
+				Metadata: metadata,
+			},
+		}) {
+			return nil
 		}
+	}
 
-		return nil
-	})
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("postgres.EventStore: failed to iterate events rows, %w", err)
+	}
+
+	return nil
 }
 
 // Append implements event.Store.
+//
+// Serialization failures caused by concurrent appends are returned
+// as a version.ConflictError, so that they can be retried.
 func (es EventStore) Append(
 	ctx context.Context,
 	id event.StreamID,
@@ -114,8 +221,8 @@ func (es EventStore) Append(
 
 		if newVersion, err = appendDomainEvents(
 			ctx, tx,
-			DefaultEventsTableName, DefaultStreamsTableName,
-			es.messageSerde,
+			es.eventsTableName, es.streamsTableName,
+			es.messageSerde, es.upcasters,
 			id, expected, events...,
 		); err != nil {
 			return fmt.Errorf("postgres.EventStore: failed to append domain events, %w", err)
@@ -123,8 +230,49 @@ func (es EventStore) Append(
 
 		return nil
 	}); err != nil {
-		return 0, err
+		return 0, conflictOnSerializationFailure(err, expected)
 	}
 
 	return newVersion, nil
 }
+
+// AppendBatch appends the Domain Events to multiple Event Streams atomically,
+// in a single transaction, returning the new versions of the Event Streams
+// in the order of the appends.
+//
+// Each append follows the same rules of Append: if one of them fails,
+// the transaction is rolled back and no Domain Event is committed.
+func (es EventStore) AppendBatch(ctx context.Context, appends ...event.StreamAppend) ([]version.Version, error) {
+	if err := event.CheckUniqueIDs(appends...); err != nil {
+		return nil, fmt.Errorf("postgres.EventStore: failed to append domain events, %w", err)
+	}
+
+	newVersions := make([]version.Version, len(appends))
+
+	txOpts := pgx.TxOptions{ //nolint:exhaustruct // We don't need all fields.
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	}
+
+	if err := internal.RunTransaction(ctx, es.conn, txOpts, func(ctx context.Context, tx pgx.Tx) error {
+		for i, app := range appends {
+			newVersion, err := appendDomainEvents(
+				ctx, tx,
+				es.eventsTableName, es.streamsTableName,
+				es.messageSerde, es.upcasters,
+				app.StreamID, app.Expected, app.Events...,
+			)
+			if err != nil {
+				return fmt.Errorf("postgres.EventStore: failed to append domain events to stream %q, %w", app.StreamID, err)
+			}
+
+			newVersions[i] = newVersion
+		}
+
+		return nil
+	}); err != nil {
+		return nil, conflictOnSerializationFailure(err, nil)
+	}
+
+	return newVersions, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/get-eventually/go-eventually/event"
+)
+
+// DefaultTailPollInterval is the default interval used by EventStore.TailAll
+// to poll the events table when no notification is received.
+const DefaultTailPollInterval = 5 * time.Second
+
+// TailAll returns a never-ending Stream of the Domain Events committed to the
+// global log, starting from the specified position.
+//
+// Differently from StreamAll, TailAll does not stop once the end of the log
+// is reached: it blocks on a dedicated connection listening for the notifications
+// sent by Append (and AggregateRepository.Save) on commit, then yields
+// the newly committed Domain Events.
+//
+// Notifications are only used as a wake-up signal: Domain Events are always
+// read from the events table, starting from the last position yielded.
+// If no notification is received within the tail poll interval, or if the
+// listening connection is lost, TailAll falls back to polling the events table
+// (reconnecting in the meantime), so that no Domain Event is missed.
+//
+// The Stream ends when the context is canceled, reporting the context error,
+// or when reading from the events table fails.
+func (es EventStore) TailAll(ctx context.Context, selector event.PositionSelector) *event.Stream {
+	return event.NewStream(func(yield func(event.Persisted) bool) error {
+		t := tail{
+			es:       es,
+			from:     selector.From,
+			listener: nil,
+		}
+
+		defer t.close()
+
+		for {
+			// NOTE: listening must start before reading the events table,
+			// or the notifications sent in the meantime would be lost.
+			t.listen(ctx)
+
+			done, err := t.catchUp(ctx, yield)
+			if err != nil || done {
+				return err
+			}
+
+			if err := t.wait(ctx); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+type tail struct {
+	es       EventStore
+	from     event.Position
+	listener *pgx.Conn
+}
+
+// listen opens a dedicated connection to LISTEN on the events table channel,
+// unless one is already open.
+//
+// Failures are not reported, since TailAll falls back to polling
+// until the connection is re-established.
+func (t *tail) listen(ctx context.Context) {
+	if t.listener != nil {
+		return
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, t.es.conn.Config().ConnConfig)
+	if err != nil {
+		return
+	}
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{t.es.eventsTableName}.Sanitize()); err != nil {
+		conn.Close(ctx) //nolint:errcheck,gosec // The connection is discarded anyway.
+
+		return
+	}
+
+	t.listener = conn
+}
+
+// catchUp yields all the Domain Events committed from the current position,
+// returning true if the consumer stopped the iteration.
+func (t *tail) catchUp(ctx context.Context, yield func(event.Persisted) bool) (bool, error) {
+	stopped := false
+	stream := t.es.StreamAll(ctx, event.PositionSelector{From: t.from})
+
+	for evt := range stream.Iter() {
+		if !yield(evt) {
+			stopped = true
+
+			break
+		}
+
+		t.from = evt.Position + 1
+	}
+
+	if err := stream.Err(); err != nil {
+		return true, fmt.Errorf("postgres.EventStore: failed to tail events, %w", err)
+	}
+
+	return stopped, nil
+}
+
+// wait blocks until either a notification is received, the tail poll interval
+// elapses or the listening connection is lost.
+func (t *tail) wait(ctx context.Context) error {
+	waitCtx, cancel := context.WithTimeout(ctx, t.es.tailPollInterval)
+	defer cancel()
+
+	if t.listener == nil {
+		<-waitCtx.Done()
+	} else if _, err := t.listener.WaitForNotification(waitCtx); err != nil && waitCtx.Err() == nil {
+		// The listening connection has been lost: it will be re-established
+		// on the next iteration, after polling for new Domain Events.
+		t.close()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("postgres.EventStore: tailing stopped, %w", err)
+	}
+
+	return nil
+}
+
+func (t *tail) close() {
+	if t.listener == nil {
+		return
+	}
+
+	t.listener.Close(context.Background()) //nolint:errcheck,gosec // The connection is discarded anyway.
+	t.listener = nil
+}
@@ -0,0 +1,122 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
+	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestEventStore_TailAll(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	// NOTE: the poll interval is long enough to make sure the
+	// Domain Events are tailed thanks to the notifications.
+	eventStore := postgres.NewEventStore(conn,
+		serde.Chain(
+			user.EventProtoSerde,
+			serde.NewProtoJSON(func() *userv1.Event { return new(userv1.Event) }),
+		),
+		postgres.WithEventStoreTailPollInterval(time.Minute),
+	)
+
+	appendUser := func(t *testing.T) event.StreamID {
+		t.Helper()
+
+		id := uuid.New()
+
+		usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		_, err = eventStore.Append(ctx, event.StreamID(id.String()), version.Any, usr.FlushRecordedEvents()...)
+		require.NoError(t, err)
+
+		return event.StreamID(id.String())
+	}
+
+	existing := appendUser(t)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tailed := make(chan event.StreamID, 10)
+	stream := eventStore.TailAll(ctx, event.SelectAllFromBeginning)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for evt := range stream.Iter() {
+			tailed <- evt.StreamID
+		}
+	}()
+
+	receive := func(t *testing.T) event.StreamID {
+		t.Helper()
+
+		select {
+		case id := <-tailed:
+			return id
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "no event tailed in time")
+
+			return ""
+		}
+	}
+
+	t.Run("already committed events are yielded first", func(t *testing.T) {
+		assert.Equal(t, existing, receive(t))
+	})
+
+	t.Run("newly committed events are yielded once notified", func(t *testing.T) {
+		assert.Equal(t, appendUser(t), receive(t))
+	})
+
+	t.Run("events committed while reconnecting are not missed", func(t *testing.T) {
+		_, err := conn.Exec(
+			ctx,
+			`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query LIKE 'LISTEN %'`,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, appendUser(t), receive(t))
+	})
+
+	cancel()
+	<-done
+
+	require.ErrorIs(t, stream.Err(), context.Canceled)
+}
@@ -1,19 +1,26 @@
 package postgres_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/get-eventually/go-eventually/event"
 	"github.com/get-eventually/go-eventually/internal/user"
 	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/message"
 	"github.com/get-eventually/go-eventually/postgres"
 	"github.com/get-eventually/go-eventually/postgres/internal"
 	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
 )
 
 func TestEventStore(t *testing.T) {
@@ -33,13 +40,134 @@ func TestEventStore(t *testing.T) {
 	db, err := sql.Open("pgx", container.ConnectionDSN)
 	require.NoError(t, err)
 	require.NoError(t, postgres.RunMigrations(db))
+
+	// Migrations for a second bounded context, sharing the same database.
+	migrationsConfigOptions := []postgres.Option[*postgres.MigrationsConfig]{
+		postgres.WithMigrationsSchemaName("billing"),
+		postgres.WithMigrationsTablePrefix("bc_"),
+	}
+
+	require.NoError(t, postgres.RunMigrations(db, migrationsConfigOptions...))
 	require.NoError(t, db.Close())
 
 	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
 	require.NoError(t, err)
 
-	user.EventStoreSuite(postgres.NewEventStore(conn, serde.Chain(
+	messageSerde := serde.Chain(
 		user.EventProtoSerde,
 		serde.NewProtoJSON(func() *userv1.Event { return new(userv1.Event) }),
-	)))(t)
+	)
+
+	t.Run("default table names", func(t *testing.T) {
+		eventStore := postgres.NewEventStore(conn, messageSerde)
+
+		user.EventStoreSuite(eventStore)(t)
+		user.AllStreamerSuite(eventStore)(t)
+	})
+
+	t.Run("custom schema and table prefix", func(t *testing.T) {
+		migrationsConfig := postgres.NewMigrationsConfig(migrationsConfigOptions...)
+
+		eventStore := postgres.NewEventStore(conn, messageSerde,
+			postgres.WithEventStoreEventsTableName(migrationsConfig.TableName(postgres.DefaultEventsTableName)),
+			postgres.WithEventStoreStreamsTableName(migrationsConfig.TableName(postgres.DefaultStreamsTableName)),
+		)
+
+		user.EventStoreSuite(eventStore)(t)
+		user.AllStreamerSuite(eventStore)(t)
+	})
+
+	t.Run("plain payloads are deserialized by type name", func(t *testing.T) {
+		registry := serde.NewRegistry()
+		require.NoError(t, serde.Register(registry, "UserWasCreated", messageSerde))
+		require.NoError(t, serde.Register(registry, "UserEmailWasUpdated", messageSerde))
+
+		t.Run("bare", func(t *testing.T) {
+			user.EventStoreSuite(postgres.NewEventStore(conn, registry.Plain()))(t)
+		})
+
+		t.Run("wrapped", func(t *testing.T) {
+			user.EventStoreSuite(postgres.NewEventStore(conn,
+				serde.Compress[message.Message](registry.Plain(), serde.CodecGzip, 0),
+			))(t)
+		})
+	})
+
+	t.Run("domain events are upcast to their current schema version", func(t *testing.T) {
+		id := uuid.New()
+
+		usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+
+		// Appended at schema version 1, with no upcaster registered yet.
+		_, err = postgres.NewEventStore(conn, messageSerde).
+			Append(ctx, event.StreamID(id.String()), version.NoStream, usr.FlushRecordedEvents()...)
+		require.NoError(t, err)
+
+		upcasters := event.NewUpcasterRegistry()
+		require.NoError(t, upcasters.Register("UserWasCreated", 1, event.UpcasterFunc(func(data []byte) ([]byte, error) {
+			return bytes.ReplaceAll(data, []byte(`"John"`), []byte(`"Johnny"`)), nil
+		})))
+
+		eventStore := postgres.NewEventStore(conn, messageSerde, postgres.WithEventStoreUpcasters(upcasters))
+		stream := eventStore.Stream(ctx, event.StreamID(id.String()), version.SelectFromBeginning)
+
+		var events []event.Persisted
+		for evt := range stream.Iter() {
+			events = append(events, evt)
+		}
+
+		require.NoError(t, stream.Err())
+		require.Len(t, events, 1)
+
+		evt, ok := events[0].Message.(*user.Event)
+		require.True(t, ok)
+
+		wasCreated, ok := evt.Kind.(*user.WasCreated)
+		require.True(t, ok)
+
+		assert.Equal(t, "Johnny", wasCreated.FirstName)
+		assert.Equal(t, "2", events[0].Metadata[event.SchemaVersionMetadataKey])
+	})
+
+	t.Run("serialization failures are returned as conflicts", func(t *testing.T) {
+		eventStore := postgres.NewEventStore(conn, messageSerde)
+		id := uuid.New()
+
+		newEvents := func(t *testing.T) []event.Envelope {
+			t.Helper()
+
+			usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+			require.NoError(t, err)
+
+			return usr.FlushRecordedEvents()
+		}
+
+		concurrentEvents := newEvents(t)
+		concurrentErr := make(chan error, 1)
+
+		err := postgres.NewUnitOfWork(conn).Run(ctx, func(ctx context.Context) error {
+			if _, err := eventStore.Append(ctx, event.StreamID(id.String()), version.Any, newEvents(t)...); err != nil {
+				return err
+			}
+
+			go func() {
+				_, err := eventStore.Append(context.Background(), event.StreamID(id.String()), version.Any, concurrentEvents...)
+				concurrentErr <- err
+			}()
+
+			// Commit only once the concurrent append is waiting for this transaction.
+			assert.Eventually(t, func() bool {
+				var waiting int
+				err := conn.QueryRow(ctx, `SELECT COUNT(*) FROM pg_locks WHERE NOT granted`).Scan(&waiting)
+
+				return err == nil && waiting > 0
+			}, 5*time.Second, 10*time.Millisecond)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.ErrorAs(t, <-concurrentErr, new(version.ConflictError))
+	})
 }
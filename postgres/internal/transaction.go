@@ -12,14 +12,37 @@ type TxBeginner interface {
 	BeginTx(ctx context.Context, options pgx.TxOptions) (pgx.Tx, error)
 }
 
+type txContextKey struct{}
+
+// ContextWithTx returns a new context carrying the specified transaction,
+// which is used by RunTransaction instead of beginning a new one.
+func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by the context, if any.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+
+	return tx, ok
+}
+
 // RunTransaction runs a critical data change path in a transaction,
 // seamlessly handling the transaction lifecycle (begin, commit, rollback).
+//
+// If the context already carries a transaction (see ContextWithTx),
+// the data change path participates in it instead, leaving its lifecycle
+// to the component that began it.
 func RunTransaction(
 	ctx context.Context,
 	db TxBeginner,
 	options pgx.TxOptions, //nolint:gocritic // The pgx API uses value semantics, will do the same here.
 	do func(ctx context.Context, tx pgx.Tx) error,
 ) (err error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return do(ctx, tx)
+	}
+
 	withContext := func(msg string, err error) error {
 		return fmt.Errorf("%s, %w", msg, err)
 	}
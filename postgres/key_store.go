@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/serde"
+)
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ serde.KeyStore = KeyStore{}
+
+// KeyStore is a serde.KeyStore implementation targeted to PostgreSQL databases.
+//
+// The implementation uses the "encryption_keys" table as its operational table,
+// unless a different one is specified with WithEncryptionKeysTableName.
+//
+// Deleted keys are kept as tombstones, so that no new key is created
+// for a forgotten data subject.
+type KeyStore struct {
+	conn      *pgxpool.Pool
+	tableName string
+}
+
+// NewKeyStore returns a new KeyStore instance.
+func NewKeyStore(conn *pgxpool.Pool, options ...Option[*KeyStore]) KeyStore {
+	store := KeyStore{
+		conn:      conn,
+		tableName: DefaultEncryptionKeysTableName,
+	}
+
+	for _, opt := range options {
+		opt.apply(&store)
+	}
+
+	return store
+}
+
+const (
+	getKeyQueryTemplate = `
+		SELECT "key"
+		FROM %s
+		WHERE subject_id = $1 AND deleted_at IS NULL
+	`
+
+	// NOTE: the no-op update makes sure the existing key, or tombstone, is returned
+	// when another one has already been created for the same data subject.
+	createKeyQueryTemplate = `
+		INSERT INTO %s (subject_id, "key")
+		VALUES ($1, $2)
+		ON CONFLICT (subject_id) DO
+		UPDATE SET subject_id = EXCLUDED.subject_id
+		RETURNING "key", deleted_at IS NOT NULL
+	`
+
+	deleteKeyQueryTemplate = `
+		INSERT INTO %s AS existing (subject_id, "key", deleted_at)
+		VALUES ($1, NULL, NOW())
+		ON CONFLICT (subject_id) DO
+		UPDATE SET "key" = NULL, deleted_at = COALESCE(existing.deleted_at, NOW())
+	`
+)
+
+// Key implements the serde.KeyStore interface.
+func (s KeyStore) Key(ctx context.Context, subjectID string) ([]byte, error) {
+	row := s.conn.QueryRow(ctx, fmt.Sprintf(getKeyQueryTemplate, s.tableName), subjectID)
+
+	var key []byte
+	if err := row.Scan(&key); errors.Is(err, pgx.ErrNoRows) {
+		return nil, serde.ErrKeyNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("postgres.KeyStore: failed to fetch key, %w", err)
+	}
+
+	return key, nil
+}
+
+// CreateKey implements the serde.KeyStore interface.
+func (s KeyStore) CreateKey(ctx context.Context, subjectID string) ([]byte, error) {
+	newKey, err := serde.NewKey()
+	if err != nil {
+		return nil, fmt.Errorf("postgres.KeyStore: failed to create key, %w", err)
+	}
+
+	row := s.conn.QueryRow(ctx, fmt.Sprintf(createKeyQueryTemplate, s.tableName), subjectID, newKey)
+
+	var (
+		key       []byte
+		forgotten bool
+	)
+
+	if err := row.Scan(&key, &forgotten); err != nil {
+		return nil, fmt.Errorf("postgres.KeyStore: failed to create key, %w", err)
+	}
+
+	if forgotten {
+		return nil, fmt.Errorf("postgres.KeyStore: failed to create key, %w", serde.ErrSubjectForgotten)
+	}
+
+	return key, nil
+}
+
+// DeleteKey implements the serde.KeyStore interface.
+func (s KeyStore) DeleteKey(ctx context.Context, subjectID string) error {
+	if _, err := s.conn.Exec(ctx, fmt.Sprintf(deleteKeyQueryTemplate, s.tableName), subjectID); err != nil {
+		return fmt.Errorf("postgres.KeyStore: failed to delete key, %w", err)
+	}
+
+	return nil
+}
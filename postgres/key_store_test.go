@@ -0,0 +1,97 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+)
+
+func TestKeyStore(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	keyStore := postgres.NewKeyStore(conn)
+
+	t.Run("key returns an error when no key exists", func(t *testing.T) {
+		_, err := keyStore.Key(ctx, "missing")
+		assert.ErrorIs(t, err, serde.ErrKeyNotFound)
+	})
+
+	t.Run("created keys are kept until deleted", func(t *testing.T) {
+		key, err := keyStore.CreateKey(ctx, "subject")
+		require.NoError(t, err)
+		assert.Len(t, key, serde.KeySize)
+
+		sameKey, err := keyStore.CreateKey(ctx, "subject")
+		require.NoError(t, err)
+		assert.Equal(t, key, sameKey)
+
+		storedKey, err := keyStore.Key(ctx, "subject")
+		require.NoError(t, err)
+		assert.Equal(t, key, storedKey)
+
+		require.NoError(t, keyStore.DeleteKey(ctx, "subject"))
+
+		_, err = keyStore.Key(ctx, "subject")
+		assert.ErrorIs(t, err, serde.ErrKeyNotFound)
+	})
+
+	t.Run("encrypted data is forgotten once the key is deleted", func(t *testing.T) {
+		shredder := serde.NewShredder(keyStore)
+
+		encrypted, err := shredder.EncryptString(ctx, "forgotten", "john@doe.com")
+		require.NoError(t, err)
+
+		decrypted, err := shredder.DecryptString(ctx, "forgotten", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "john@doe.com", decrypted)
+
+		require.NoError(t, keyStore.DeleteKey(ctx, "forgotten"))
+
+		decrypted, err = shredder.DecryptString(ctx, "forgotten", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, serde.ForgottenPlaceholder, decrypted)
+
+		_, err = shredder.EncryptString(ctx, "forgotten", "john.doe@mail.com")
+		require.ErrorIs(t, err, serde.ErrSubjectForgotten)
+
+		require.NoError(t, keyStore.DeleteKey(ctx, "forgotten"))
+
+		_, err = keyStore.Key(ctx, "forgotten")
+		require.ErrorIs(t, err, serde.ErrKeyNotFound)
+	})
+
+	t.Run("subjects with no key can be forgotten in advance", func(t *testing.T) {
+		require.NoError(t, keyStore.DeleteKey(ctx, "never-seen"))
+
+		_, err := keyStore.CreateKey(ctx, "never-seen")
+		require.ErrorIs(t, err, serde.ErrSubjectForgotten)
+	})
+}
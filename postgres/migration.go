@@ -1,10 +1,16 @@
 package postgres
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/pgx"
@@ -12,26 +18,117 @@ import (
 )
 
 //go:embed migrations/*.sql
-var fs embed.FS
+var migrationsFS embed.FS
+
+// DefaultMigrationsTableName is the default table name used to keep track
+// of the migrations applied by RunMigrations.
+const DefaultMigrationsTableName = "eventually_schema_migrations"
+
+var (
+	schemaNameRegexp  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	tablePrefixRegexp = regexp.MustCompile(`^[A-Za-z0-9_]*$`)
+)
+
+// MigrationsConfig contains the configuration used by RunMigrations.
+//
+// Use the available functional options to change it.
+type MigrationsConfig struct {
+	schemaName  string
+	tablePrefix string
+}
+
+// WithMigrationsSchemaName makes RunMigrations create all the tables
+// under the specified schema, which is created if it does not exist.
+//
+// The migrations tracking table is also created under the same schema.
+func WithMigrationsSchemaName(schemaName string) Option[*MigrationsConfig] {
+	return newOption(func(cfg *MigrationsConfig) {
+		cfg.schemaName = schemaName
+	})
+}
+
+// WithMigrationsTablePrefix makes RunMigrations prepend the specified prefix
+// to the name of all the tables and indexes it creates, including
+// the migrations tracking table.
+func WithMigrationsTablePrefix(prefix string) Option[*MigrationsConfig] {
+	return newOption(func(cfg *MigrationsConfig) {
+		cfg.tablePrefix = prefix
+	})
+}
+
+// TableName returns the name of the table created by RunMigrations
+// using the current configuration, given its default name.
+//
+// Useful to configure the table names used by the postgres components,
+// e.g. TableName(DefaultEventsTableName) used with WithEventStoreEventsTableName.
+func (cfg MigrationsConfig) TableName(name string) string {
+	if cfg.schemaName == "" {
+		return cfg.tablePrefix + name
+	}
+
+	return cfg.schemaName + "." + cfg.tablePrefix + name
+}
+
+func (cfg MigrationsConfig) validate() error {
+	if cfg.schemaName != "" && !schemaNameRegexp.MatchString(cfg.schemaName) {
+		return fmt.Errorf("invalid schema name: %q", cfg.schemaName)
+	}
+
+	if !tablePrefixRegexp.MatchString(cfg.tablePrefix) {
+		return fmt.Errorf("invalid table prefix: %q", cfg.tablePrefix)
+	}
+
+	return nil
+}
+
+// NewMigrationsConfig returns the MigrationsConfig resulting from the
+// provided options.
+func NewMigrationsConfig(options ...Option[*MigrationsConfig]) MigrationsConfig {
+	var cfg MigrationsConfig
+
+	for _, opt := range options {
+		opt.apply(&cfg)
+	}
+
+	return cfg
+}
 
 // RunMigrations runs the latest migrations for the postgres integration.
 //
 // Make sure to run these in the entrypoint of your application, ideally
 // before building a postgres interface implementation.
-func RunMigrations(db *sql.DB) error {
+//
+// By default, tables are created in the current schema using the Default*TableName
+// names. Use WithMigrationsSchemaName and WithMigrationsTablePrefix to create them
+// under a different schema or prefix, e.g. to host multiple bounded contexts
+// in the same database. Make sure to configure the postgres components with the
+// resulting table names (see MigrationsConfig.TableName).
+func RunMigrations(db *sql.DB, options ...Option[*MigrationsConfig]) error {
 	wrapErr := func(err error, msg string) error {
 		return fmt.Errorf("postgres.RunMigrations: %s, %w", msg, err)
 	}
 
-	d, err := iofs.New(fs, "migrations")
+	cfg := NewMigrationsConfig(options...)
+
+	if err := cfg.validate(); err != nil {
+		return wrapErr(err, "invalid configuration")
+	}
+
+	if cfg.schemaName != "" {
+		if _, err := db.ExecContext(context.Background(), "CREATE SCHEMA IF NOT EXISTS "+cfg.schemaName); err != nil {
+			return wrapErr(err, "failed to create schema")
+		}
+	}
+
+	d, err := iofs.New(templateFS{fs: migrationsFS, cfg: cfg}, "migrations")
 	if err != nil {
 		return wrapErr(err, "failed to create new iofs driver for reading migrations")
 	}
 
 	driver, err := pgx.WithInstance(db, &pgx.Config{ //nolint:exhaustruct // We don't need all fields.
-		MigrationsTable:       "eventually_schema_migrations",
+		MigrationsTable:       cfg.tablePrefix + DefaultMigrationsTableName,
 		DatabaseName:          "",
-		SchemaName:            "",
+		SchemaName:            cfg.schemaName,
 		StatementTimeout:      0,
 		MigrationsTableQuoted: false,
 		MultiStatementEnabled: false,
@@ -52,3 +149,53 @@ func RunMigrations(db *sql.DB) error {
 
 	return nil
 }
+
+// templateFS is an fs.FS that renders the migration files as text templates,
+// to qualify the names of the database objects with the configured schema and prefix.
+type templateFS struct {
+	fs  fs.FS
+	cfg MigrationsConfig
+}
+
+func (tfs templateFS) Open(name string) (fs.File, error) {
+	file, err := tfs.fs.Open(name)
+	if err != nil || !strings.HasSuffix(name, ".sql") {
+		return file, err //nolint:wrapcheck // Must behave like the wrapped fs.FS.
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Must behave like the wrapped fs.FS.
+	}
+
+	content, err := fs.ReadFile(tfs.fs, name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Must behave like the wrapped fs.FS.
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"qualified": tfs.cfg.TableName,
+		"prefixed":  func(name string) string { return tfs.cfg.tablePrefix + name },
+	}).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("postgres.templateFS: failed to parse migration %s, %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, nil); err != nil {
+		return nil, fmt.Errorf("postgres.templateFS: failed to render migration %s, %w", name, err)
+	}
+
+	return renderedFile{info: info, Reader: bytes.NewReader(rendered.Bytes())}, nil
+}
+
+type renderedFile struct {
+	*bytes.Reader
+
+	info fs.FileInfo
+}
+
+func (f renderedFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (renderedFile) Close() error                 { return nil }
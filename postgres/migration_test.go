@@ -0,0 +1,49 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/get-eventually/go-eventually/postgres"
+)
+
+func TestRunMigrations_InvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []postgres.Option[*postgres.MigrationsConfig]
+	}{
+		{
+			name:    "schema name with SQL",
+			options: []postgres.Option[*postgres.MigrationsConfig]{postgres.WithMigrationsSchemaName("billing; DROP TABLE events")},
+		},
+		{
+			name:    "schema name starting with a digit",
+			options: []postgres.Option[*postgres.MigrationsConfig]{postgres.WithMigrationsSchemaName("1billing")},
+		},
+		{
+			name:    "table prefix with a dot",
+			options: []postgres.Option[*postgres.MigrationsConfig]{postgres.WithMigrationsTablePrefix("bc.")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The configuration is validated before accessing the database.
+			assert.Error(t, postgres.RunMigrations(nil, tc.options...))
+		})
+	}
+}
+
+func TestMigrationsConfig_TableName(t *testing.T) {
+	assert.Equal(t, "events", postgres.NewMigrationsConfig().TableName(postgres.DefaultEventsTableName))
+
+	assert.Equal(t, "bc_events", postgres.NewMigrationsConfig(
+		postgres.WithMigrationsTablePrefix("bc_"),
+	).TableName(postgres.DefaultEventsTableName))
+
+	assert.Equal(t, "billing.bc_events", postgres.NewMigrationsConfig(
+		postgres.WithMigrationsSchemaName("billing"),
+		postgres.WithMigrationsTablePrefix("bc_"),
+	).TableName(postgres.DefaultEventsTableName))
+}
@@ -0,0 +1 @@
+DROP TABLE {{ qualified "command_outcomes" }};
@@ -0,0 +1,10 @@
+-- The outcomes of the Commands handled, used to detect duplicate Commands
+-- until they expire.
+CREATE TABLE {{ qualified "command_outcomes" }} (
+    command_id  TEXT        NOT NULL PRIMARY KEY,
+    "error"     TEXT        NOT NULL DEFAULT '',
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX {{ prefixed "command_outcomes_expires_at_idx" }} ON {{ qualified "command_outcomes" }} (expires_at);
@@ -0,0 +1,2 @@
+DROP INDEX {{ qualified "events_event_id_idx" }};
+ALTER TABLE {{ qualified "events" }} DROP COLUMN event_id;
@@ -0,0 +1,12 @@
+-- Adds the unique identifier of the Domain Events, taken from the Message-ID metadata,
+-- used to detect retried appends of the same Domain Events.
+--
+-- NOTE: Domain Events with no identifier are stored with a NULL event_id,
+-- which is not subject to the unique constraint.
+ALTER TABLE {{ qualified "events" }} ADD COLUMN event_id TEXT;
+
+UPDATE {{ qualified "events" }}
+SET event_id = metadata->>'Message-ID'
+WHERE metadata ? 'Message-ID';
+
+CREATE UNIQUE INDEX {{ prefixed "events_event_id_idx" }} ON {{ qualified "events" }} (event_id);
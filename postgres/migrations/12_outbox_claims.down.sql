@@ -0,0 +1,7 @@
+DROP INDEX {{ qualified "outbox_pending_idx" }};
+
+CREATE INDEX {{ prefixed "outbox_pending_idx" }} ON {{ qualified "outbox" }} (id) WHERE delivered_at IS NULL;
+
+ALTER TABLE {{ qualified "outbox" }}
+    DROP COLUMN claimed_until,
+    DROP COLUMN failed_at;
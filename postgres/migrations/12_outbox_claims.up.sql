@@ -0,0 +1,13 @@
+-- Outbox entries are claimed by an OutboxRelay for a limited time, and published
+-- outside of the claiming transaction.
+--
+-- Entries that could not be published within the maximum number of attempts
+-- are marked as failed and set aside, so that they do not block the entries behind them.
+ALTER TABLE {{ qualified "outbox" }}
+    ADD COLUMN claimed_until TIMESTAMPTZ,
+    ADD COLUMN failed_at     TIMESTAMPTZ;
+
+DROP INDEX {{ qualified "outbox_pending_idx" }};
+
+CREATE INDEX {{ prefixed "outbox_pending_idx" }} ON {{ qualified "outbox" }} (id)
+WHERE delivered_at IS NULL AND failed_at IS NULL;
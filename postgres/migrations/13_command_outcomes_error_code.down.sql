@@ -0,0 +1 @@
+ALTER TABLE {{ qualified "command_outcomes" }} DROP COLUMN error_code;
@@ -0,0 +1,3 @@
+-- The code of the error returned when handling a Command, if it matches
+-- one of the errors specified with command.WithDeduplicationErrorCodes.
+ALTER TABLE {{ qualified "command_outcomes" }} ADD COLUMN error_code TEXT NOT NULL DEFAULT '';
@@ -1,2 +1,2 @@
-DROP TABLE events;
-DROP TABLE event_streams;
+DROP TABLE {{ qualified "events" }};
+DROP TABLE {{ qualified "event_streams" }};
@@ -1,9 +1,9 @@
-CREATE TABLE event_streams (
+CREATE TABLE {{ qualified "event_streams" }} (
     event_stream_id TEXT    NOT NULL PRIMARY KEY,
     "version"       INTEGER NOT NULL CHECK ("version" > 0)
 );
 
-CREATE TABLE events (
+CREATE TABLE {{ qualified "events" }} (
     event_stream_id  TEXT    NOT NULL,
     "type"           TEXT    NOT NULL,
     "version"        INTEGER NOT NULL CHECK ("version" > 0),
@@ -11,12 +11,12 @@ CREATE TABLE events (
     metadata         JSONB,
 
     PRIMARY KEY (event_stream_id, "version"),
-    FOREIGN KEY (event_stream_id) REFERENCES event_streams (event_stream_id) ON DELETE CASCADE
+    FOREIGN KEY (event_stream_id) REFERENCES {{ qualified "event_streams" }} (event_stream_id) ON DELETE CASCADE
 );
 
-CREATE INDEX event_stream_id_idx ON events (event_stream_id);
+CREATE INDEX {{ prefixed "event_stream_id_idx" }} ON {{ qualified "events" }} (event_stream_id);
 
-CREATE PROCEDURE upsert_event_stream(
+CREATE PROCEDURE {{ qualified "upsert_event_stream" }}(
     _event_stream_id TEXT,
     _expected_version INTEGER,
     _new_version INTEGER
@@ -29,7 +29,7 @@ BEGIN
     -- Retrieve the latest version for the target Event Stream.
     SELECT es."version"
     INTO current_event_stream_version
-    FROM event_streams es
+    FROM {{ qualified "event_streams" }} es
     WHERE es.event_stream_id = _event_stream_id;
 
     IF (NOT FOUND AND _expected_version <> 0) OR (current_event_stream_version <> _expected_version)
@@ -37,14 +37,14 @@ BEGIN
         RAISE EXCEPTION 'event stream version check failed, expected: %, got: %', _expected_version, current_event_stream_version;
     END IF;
 
-    INSERT INTO event_streams (event_stream_id, "version")
+    INSERT INTO {{ qualified "event_streams" }} (event_stream_id, "version")
     VALUES (_event_stream_id, _new_version)
     ON CONFLICT (event_stream_id) DO
     UPDATE SET "version" = _new_version;
 END;
 $$;
 
-CREATE FUNCTION upsert_event_stream_with_no_version_check(
+CREATE FUNCTION {{ qualified "upsert_event_stream_with_no_version_check" }}(
     _event_stream_id TEXT,
     _new_version_offset INTEGER
 )
@@ -58,7 +58,7 @@ BEGIN
     -- Retrieve the latest version for the target Event Stream.
     SELECT es."version"
     INTO current_event_stream_version
-    FROM event_streams es
+    FROM {{ qualified "event_streams" }} es
     WHERE es.event_stream_id = _event_stream_id;
 
     IF NOT FOUND THEN
@@ -67,7 +67,7 @@ BEGIN
 
     new_event_stream_version := current_event_stream_version + _new_version_offset;
 
-    INSERT INTO event_streams (event_stream_id, "version")
+    INSERT INTO {{ qualified "event_streams" }} (event_stream_id, "version")
     VALUES (_event_stream_id, new_event_stream_version)
     ON CONFLICT (event_stream_id) DO
     UPDATE SET "version" = new_event_stream_version;
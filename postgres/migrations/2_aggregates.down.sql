@@ -1,2 +1,2 @@
-DROP TABLE aggregates;
-DROP PROCEDURE upsert_aggregate;
+DROP TABLE {{ qualified "aggregates" }};
+DROP PROCEDURE {{ qualified "upsert_aggregate" }};
@@ -1,11 +1,11 @@
-CREATE TABLE aggregates (
-    aggregate_id TEXT    NOT NULL PRIMARY KEY REFERENCES event_streams (event_stream_id) ON DELETE CASCADE,
+CREATE TABLE {{ qualified "aggregates" }} (
+    aggregate_id TEXT    NOT NULL PRIMARY KEY REFERENCES {{ qualified "event_streams" }} (event_stream_id) ON DELETE CASCADE,
     "type"       TEXT    NOT NULL,
     "version"    INTEGER NOT NULL CHECK ("version" > 0),
     "state"      BYTEA   NOT NULL
 );
 
-CREATE PROCEDURE upsert_aggregate(
+CREATE PROCEDURE {{ qualified "upsert_aggregate" }}(
     _aggregate_id TEXT,
     _type TEXT,
     _expected_version INTEGER,
@@ -20,7 +20,7 @@ BEGIN
     -- Retrieve the latest version for the target aggregate.
     SELECT a."version"
     INTO current_aggregate_version
-    FROM aggregates a
+    FROM {{ qualified "aggregates" }} a
     WHERE a.aggregate_id = _aggregate_id;
 
     IF (NOT FOUND AND _expected_version <> 0) OR (current_aggregate_version <> _expected_version)
@@ -29,12 +29,12 @@ BEGIN
     END IF;
 
     -- An Aggregate Root is also an Event Stream.
-    INSERT INTO event_streams (event_stream_id, "version")
+    INSERT INTO {{ qualified "event_streams" }} (event_stream_id, "version")
     VALUES (_aggregate_id, _new_version)
     ON CONFLICT (event_stream_id) DO
     UPDATE SET "version" = _new_version;
 
-    INSERT INTO aggregates (aggregate_id, "type", "version", "state")
+    INSERT INTO {{ qualified "aggregates" }} (aggregate_id, "type", "version", "state")
     VALUES (_aggregate_id, _type, _new_version, _state)
     ON CONFLICT (aggregate_id) DO
     UPDATE SET "version" = _new_version, "state" = _state;
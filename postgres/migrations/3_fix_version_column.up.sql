@@ -1,3 +1,3 @@
-ALTER TABLE event_streams ALTER COLUMN "version" TYPE BIGINT;
-ALTER TABLE events ALTER COLUMN "version" TYPE BIGINT;
-ALTER TABLE aggregates ALTER COLUMN "version" TYPE BIGINT;
+ALTER TABLE {{ qualified "event_streams" }} ALTER COLUMN "version" TYPE BIGINT;
+ALTER TABLE {{ qualified "events" }} ALTER COLUMN "version" TYPE BIGINT;
+ALTER TABLE {{ qualified "aggregates" }} ALTER COLUMN "version" TYPE BIGINT;
@@ -1,3 +1,3 @@
-DROP PROCEDURE upsert_aggregate;
-DROP PROCEDURE upsert_event_stream;
-DROP FUNCTION upsert_event_stream_with_no_version_check;
\ No newline at end of file
+DROP PROCEDURE {{ qualified "upsert_aggregate" }};
+DROP PROCEDURE {{ qualified "upsert_event_stream" }};
+DROP FUNCTION {{ qualified "upsert_event_stream_with_no_version_check" }};
\ No newline at end of file
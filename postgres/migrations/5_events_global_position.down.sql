@@ -0,0 +1,2 @@
+DROP INDEX {{ qualified "events_global_position_idx" }};
+ALTER TABLE {{ qualified "events" }} DROP COLUMN global_position;
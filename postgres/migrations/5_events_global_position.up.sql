@@ -0,0 +1,8 @@
+-- Adds a global, monotonically increasing position to all the Domain Events
+-- in the events table, used to stream the Event Store log across all Event Streams.
+--
+-- NOTE: events already existing in the table are assigned a position
+-- in no particular order.
+ALTER TABLE {{ qualified "events" }} ADD COLUMN global_position BIGINT GENERATED ALWAYS AS IDENTITY;
+
+CREATE UNIQUE INDEX {{ prefixed "events_global_position_idx" }} ON {{ qualified "events" }} (global_position);
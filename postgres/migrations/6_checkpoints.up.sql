@@ -0,0 +1,5 @@
+CREATE TABLE {{ qualified "checkpoints" }} (
+    subscription_name TEXT        NOT NULL PRIMARY KEY,
+    "position"        BIGINT      NOT NULL CHECK ("position" >= 0),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
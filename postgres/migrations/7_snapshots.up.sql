@@ -0,0 +1,6 @@
+CREATE TABLE {{ qualified "snapshots" }} (
+    event_stream_id TEXT        NOT NULL PRIMARY KEY REFERENCES {{ qualified "event_streams" }} (event_stream_id) ON DELETE CASCADE,
+    "version"       BIGINT      NOT NULL CHECK ("version" > 0),
+    "state"         BYTEA       NOT NULL,
+    recorded_at     TIMESTAMPTZ NOT NULL
+);
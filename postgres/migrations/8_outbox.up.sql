@@ -0,0 +1,17 @@
+-- The outbox table is written in the same transaction that appends the Domain Events,
+-- and it is used to relay them to external systems with at-least-once semantics.
+CREATE TABLE {{ qualified "outbox" }} (
+    id              BIGINT      GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    event_stream_id TEXT        NOT NULL,
+    "type"          TEXT        NOT NULL,
+    "version"       BIGINT      NOT NULL CHECK ("version" > 0),
+    global_position BIGINT      NOT NULL,
+    "event"         BYTEA       NOT NULL,
+    metadata        JSONB,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX {{ prefixed "outbox_pending_idx" }} ON {{ qualified "outbox" }} (id) WHERE delivered_at IS NULL;
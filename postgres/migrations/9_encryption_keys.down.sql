@@ -0,0 +1 @@
+DROP TABLE {{ qualified "encryption_keys" }};
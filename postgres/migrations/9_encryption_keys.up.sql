@@ -0,0 +1,11 @@
+-- The encryption keys used to encrypt the personal data of each data subject.
+-- Deleting a key makes all the data encrypted with it unreadable (crypto-shredding):
+-- the row is kept with no key, so that no new key can be created for the data subject.
+CREATE TABLE {{ qualified "encryption_keys" }} (
+    subject_id TEXT        NOT NULL PRIMARY KEY,
+    "key"      BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    CHECK (("key" IS NULL) = (deleted_at IS NOT NULL))
+);
@@ -1,6 +1,11 @@
 package postgres
 
-import "github.com/get-eventually/go-eventually/aggregate"
+import (
+	"time"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/event"
+)
 
 // Option can be used to change the configuration of an object.
 type Option[T any] interface {
@@ -20,6 +25,16 @@ const (
 	DefaultEventsTableName = "events"
 	// DefaultStreamsTableName is the default Event Streams table name an AggregateRepository points to.
 	DefaultStreamsTableName = "event_streams"
+	// DefaultCheckpointsTableName is the default subscription checkpoints table name a Checkpointer points to.
+	DefaultCheckpointsTableName = "checkpoints"
+	// DefaultSnapshotsTableName is the default Aggregate snapshots table name a SnapshotStore points to.
+	DefaultSnapshotsTableName = "snapshots"
+	// DefaultOutboxTableName is the default outbox table name an OutboxRelay points to.
+	DefaultOutboxTableName = "outbox"
+	// DefaultEncryptionKeysTableName is the default encryption keys table name a KeyStore points to.
+	DefaultEncryptionKeysTableName = "encryption_keys"
+	// DefaultCommandOutcomesTableName is the default Command outcomes table name a DeduplicationStore points to.
+	DefaultCommandOutcomesTableName = "command_outcomes"
 )
 
 // WithAggregateTableName allows you to specify a different Aggregate table name
@@ -47,3 +62,92 @@ func WithStreamsTableName[ID aggregate.ID, T aggregate.Root[ID]](tableName strin
 		repository.streamsTableName = tableName
 	})
 }
+
+// WithOutbox makes an AggregateRepository write the recorded Domain Events
+// to the specified outbox table, in the same transaction used to append them.
+//
+// Use an OutboxRelay to publish the Domain Events written in the outbox table.
+func WithOutbox[ID aggregate.ID, T aggregate.Root[ID]](tableName string) Option[*AggregateRepository[ID, T]] {
+	return newOption(func(repository *AggregateRepository[ID, T]) {
+		repository.outboxTableName = tableName
+	})
+}
+
+// WithUpcasters makes an AggregateRepository record the current schema version
+// of the Domain Events it appends, as specified by the provided event.UpcasterRegistry.
+//
+// Use the same event.UpcasterRegistry with WithEventStoreUpcasters to upcast
+// the Domain Events when reading them back from an EventStore.
+func WithUpcasters[ID aggregate.ID, T aggregate.Root[ID]](
+	upcasters *event.UpcasterRegistry,
+) Option[*AggregateRepository[ID, T]] {
+	return newOption(func(repository *AggregateRepository[ID, T]) {
+		repository.upcasters = upcasters
+	})
+}
+
+// WithEventStoreEventsTableName allows you to specify a different Events table name
+// that an EventStore should manage.
+func WithEventStoreEventsTableName(tableName string) Option[*EventStore] {
+	return newOption(func(es *EventStore) {
+		es.eventsTableName = tableName
+	})
+}
+
+// WithEventStoreStreamsTableName allows you to specify a different Event Streams table name
+// that an EventStore should manage.
+func WithEventStoreStreamsTableName(tableName string) Option[*EventStore] {
+	return newOption(func(es *EventStore) {
+		es.streamsTableName = tableName
+	})
+}
+
+// WithEventStoreTailPollInterval specifies the interval used by EventStore.TailAll
+// to poll the events table when no notification is received.
+func WithEventStoreTailPollInterval(interval time.Duration) Option[*EventStore] {
+	return newOption(func(es *EventStore) {
+		es.tailPollInterval = interval
+	})
+}
+
+// WithEventStoreUpcasters makes an EventStore record the current schema version
+// of the Domain Events it appends, and upcast the Domain Events it reads
+// to their current schema version before deserializing them,
+// using the provided event.UpcasterRegistry.
+func WithEventStoreUpcasters(upcasters *event.UpcasterRegistry) Option[*EventStore] {
+	return newOption(func(es *EventStore) {
+		es.upcasters = upcasters
+	})
+}
+
+// WithCheckpointsTableName allows you to specify a different checkpoints table name
+// that a Checkpointer should manage.
+func WithCheckpointsTableName(tableName string) Option[*Checkpointer] {
+	return newOption(func(checkpointer *Checkpointer) {
+		checkpointer.tableName = tableName
+	})
+}
+
+// WithSnapshotsTableName allows you to specify a different snapshots table name
+// that a SnapshotStore should manage.
+func WithSnapshotsTableName(tableName string) Option[*SnapshotStore] {
+	return newOption(func(store *SnapshotStore) {
+		store.tableName = tableName
+	})
+}
+
+// WithEncryptionKeysTableName allows you to specify a different encryption keys table name
+// that a KeyStore should manage.
+func WithEncryptionKeysTableName(tableName string) Option[*KeyStore] {
+	return newOption(func(store *KeyStore) {
+		store.tableName = tableName
+	})
+}
+
+// WithCommandOutcomesTableName allows you to specify a different Command outcomes table name
+// that a DeduplicationStore should manage.
+func WithCommandOutcomesTableName(tableName string) Option[*DeduplicationStore] {
+	return newOption(func(store *DeduplicationStore) {
+		store.tableName = tableName
+	})
+}
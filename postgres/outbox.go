@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+// NOTE: the Domain Events are copied from the events table, rather than
+// serialized again, so that the outbox entries carry exactly the same
+// payload, metadata and global position of the committed Domain Events.
+const appendToOutboxQueryTemplate = `
+	INSERT INTO %s (event_stream_id, "type", "version", global_position, "event", metadata)
+	SELECT event_stream_id, "type", "version", global_position, "event", metadata
+	FROM %s
+	WHERE event_stream_id = $1 AND "version" > $2
+	ORDER BY "version"
+`
+
+// appendToOutbox writes all the Domain Events of the specified Event Stream
+// appended after the provided version to the outbox table.
+//
+// It must be called in the same transaction used by appendDomainEvents.
+func appendToOutbox(
+	ctx context.Context,
+	tx pgx.Tx,
+	outboxTableName, eventsTableName string,
+	id event.StreamID,
+	after version.Version,
+) error {
+	if _, err := tx.Exec(
+		ctx,
+		fmt.Sprintf(appendToOutboxQueryTemplate, outboxTableName, eventsTableName),
+		id, after,
+	); err != nil {
+		return fmt.Errorf("postgres.appendToOutbox: failed to append domain events to outbox, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+const (
+	// DefaultOutboxRelayBatchSize is the default maximum number of outbox entries
+	// an OutboxRelay reads at once.
+	DefaultOutboxRelayBatchSize = 100
+	// DefaultOutboxRelayPollInterval is the default interval used by an OutboxRelay
+	// to poll the outbox table for new entries, once all pending ones have been relayed.
+	DefaultOutboxRelayPollInterval = 500 * time.Millisecond
+	// DefaultOutboxRelayMaxAttempts is the default number of times an OutboxRelay
+	// tries to publish an outbox entry before giving up.
+	DefaultOutboxRelayMaxAttempts = 5
+	// DefaultOutboxRelayInitialBackoff is the default time an OutboxRelay waits
+	// before retrying to publish an outbox entry for the first time.
+	DefaultOutboxRelayInitialBackoff = 100 * time.Millisecond
+	// DefaultOutboxRelayMaxBackoff is the default maximum time an OutboxRelay waits
+	// between two attempts to publish an outbox entry.
+	DefaultOutboxRelayMaxBackoff = 10 * time.Second
+	// DefaultOutboxRelayClaimTimeout is the default time an OutboxRelay holds
+	// a claim on a batch of outbox entries, while publishing them.
+	DefaultOutboxRelayClaimTimeout = time.Minute
+)
+
+// WithOutboxRelayTableName allows you to specify a different outbox table name
+// that an OutboxRelay should read from.
+func WithOutboxRelayTableName(tableName string) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.tableName = tableName
+	})
+}
+
+// WithOutboxRelayBatchSize specifies the maximum number of outbox entries
+// an OutboxRelay reads at once.
+func WithOutboxRelayBatchSize(size int) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.batchSize = size
+	})
+}
+
+// WithOutboxRelayPollInterval specifies the interval used by an OutboxRelay
+// to poll the outbox table for new entries, once all pending ones have been relayed.
+func WithOutboxRelayPollInterval(interval time.Duration) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.pollInterval = interval
+	})
+}
+
+// WithOutboxRelayMaxAttempts specifies the number of times an OutboxRelay
+// tries to publish an outbox entry before giving up.
+func WithOutboxRelayMaxAttempts(attempts int) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.maxAttempts = attempts
+	})
+}
+
+// WithOutboxRelayBackoff specifies the exponential backoff used by an OutboxRelay
+// between two attempts to publish an outbox entry: the first retry waits
+// for the initial duration, which doubles on every further retry up to max.
+func WithOutboxRelayBackoff(initial, maxBackoff time.Duration) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.initialBackoff = initial
+		relay.maxBackoff = maxBackoff
+	})
+}
+
+// WithOutboxRelayClaimTimeout specifies how long an OutboxRelay holds
+// a claim on a batch of outbox entries, while publishing them.
+//
+// The timeout should be way longer than the time needed to publish
+// an outbox entry, including its retries: entries not published in time
+// are left to the next batch.
+func WithOutboxRelayClaimTimeout(timeout time.Duration) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.claimTimeout = timeout
+	})
+}
+
+// WithOutboxRelayUpcasters makes an OutboxRelay upcast the Domain Events
+// to their current schema version before deserializing them,
+// using the provided event.UpcasterRegistry.
+func WithOutboxRelayUpcasters(upcasters *event.UpcasterRegistry) Option[*OutboxRelay] {
+	return newOption(func(relay *OutboxRelay) {
+		relay.upcasters = upcasters
+	})
+}
+
+// OutboxRelay relays the Domain Events written in the outbox table
+// to an event.Publisher, e.g. a message broker.
+//
+// Outbox entries are read and published in the order they have been committed.
+// Once published, an entry is marked as delivered; failed attempts are
+// retried with an exponential backoff, and recorded in the outbox table
+// together with the last publishing error.
+//
+// Entries that could not be published within the maximum number of attempts
+// are marked as failed and set aside, so that they do not block the entries
+// behind them: use RequeueFailed to publish them again.
+//
+// Domain Events are published with at-least-once semantics: the event.Publisher
+// might receive the same Domain Event again in case of failures between
+// publishing and marking the entry as delivered.
+//
+// Entries are claimed in batches through a short transaction, and published
+// outside of it: no transaction is kept open while publishing.
+//
+// Multiple OutboxRelay instances can run on the same outbox table:
+// a batch is claimed only when no other claim is active, to preserve the ordering.
+type OutboxRelay struct {
+	conn           *pgxpool.Pool
+	messageSerde   serde.Bytes[message.Message]
+	publisher      event.Publisher
+	tableName      string
+	batchSize      int
+	pollInterval   time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	claimTimeout   time.Duration
+	upcasters      *event.UpcasterRegistry
+}
+
+// NewOutboxRelay returns a new OutboxRelay instance, that publishes the Domain Events
+// found in the outbox table through the provided event.Publisher.
+//
+// The messageSerde must be the same used to write the Domain Events
+// in the first place, e.g. by an AggregateRepository: use WithOutboxRelayUpcasters
+// if their schema has changed in the meantime.
+func NewOutboxRelay(
+	conn *pgxpool.Pool,
+	messageSerde serde.Bytes[message.Message],
+	publisher event.Publisher,
+	options ...Option[*OutboxRelay],
+) *OutboxRelay {
+	relay := &OutboxRelay{
+		conn:           conn,
+		messageSerde:   messageSerde,
+		publisher:      publisher,
+		tableName:      DefaultOutboxTableName,
+		batchSize:      DefaultOutboxRelayBatchSize,
+		pollInterval:   DefaultOutboxRelayPollInterval,
+		maxAttempts:    DefaultOutboxRelayMaxAttempts,
+		initialBackoff: DefaultOutboxRelayInitialBackoff,
+		maxBackoff:     DefaultOutboxRelayMaxBackoff,
+		claimTimeout:   DefaultOutboxRelayClaimTimeout,
+		upcasters:      nil,
+	}
+
+	for _, opt := range options {
+		opt.apply(relay)
+	}
+
+	return relay
+}
+
+// Run starts relaying the outbox entries, blocking until the context is canceled.
+//
+// When the context is canceled, Run returns the context error.
+// Outbox entries that could not be published within the maximum number of attempts
+// are marked as failed and set aside, without stopping the relay (see RequeueFailed).
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	for {
+		claimed, _, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		if claimed == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("postgres.OutboxRelay: relay stopped, %w", ctx.Err())
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+const (
+	lockOutboxQuery = `SELECT pg_advisory_xact_lock(hashtext($1))`
+
+	// NOTE: entries are claimed only if no other claim is active,
+	// to preserve the ordering when multiple relays run on the same table.
+	claimPendingOutboxQueryTemplate = `
+		UPDATE %[1]s
+		SET claimed_until = NOW() + $2::INTERVAL
+		WHERE id IN (
+			SELECT id
+			FROM %[1]s
+			WHERE delivered_at IS NULL AND failed_at IS NULL
+			ORDER BY id
+			LIMIT $1
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM %[1]s
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND claimed_until > NOW()
+		)
+		RETURNING id, event_stream_id, "type", "version", global_position, "event", metadata
+	`
+
+	releaseOutboxClaimsQueryTemplate = `
+		UPDATE %s
+		SET claimed_until = NULL
+		WHERE id = ANY($1) AND delivered_at IS NULL
+	`
+
+	markOutboxDeliveredQueryTemplate = `
+		UPDATE %s
+		SET delivered_at = NOW(), claimed_until = NULL, attempts = attempts + 1, last_error = NULL
+		WHERE id = $1
+	`
+
+	markOutboxAttemptFailedQueryTemplate = `
+		UPDATE %s
+		SET attempts = attempts + 1, last_error = $2
+		WHERE id = $1
+	`
+
+	markOutboxFailedQueryTemplate = `
+		UPDATE %s
+		SET failed_at = NOW(), claimed_until = NULL, last_error = $2
+		WHERE id = $1
+	`
+
+	requeueFailedOutboxQueryTemplate = `
+		UPDATE %s
+		SET failed_at = NULL, attempts = 0
+		WHERE failed_at IS NOT NULL
+	`
+)
+
+type outboxEntry struct {
+	id          int64
+	streamID    event.StreamID
+	eventType   string
+	version     version.Version
+	position    event.Position
+	rawEvent    []byte
+	rawMetadata json.RawMessage
+}
+
+// RelayPending publishes the next batch of pending outbox entries,
+// returning the number of entries successfully published.
+//
+// Entries that could not be published within the maximum number of attempts
+// are marked as failed, and are not part of the returned number.
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	_, relayed, err := r.relayBatch(ctx)
+
+	return relayed, err
+}
+
+// RequeueFailed marks the outbox entries that failed to be published as pending again,
+// returning the number of requeued entries.
+//
+// Requeued entries are published again in their original order, but after
+// the entries that have been delivered while they were set aside.
+func (r *OutboxRelay) RequeueFailed(ctx context.Context) (int64, error) {
+	tag, err := r.conn.Exec(ctx, fmt.Sprintf(requeueFailedOutboxQueryTemplate, r.tableName))
+	if err != nil {
+		return 0, fmt.Errorf("postgres.OutboxRelay: failed to requeue failed outbox entries, %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// relayBatch claims the next batch of pending outbox entries, and publishes them
+// outside of the claiming transaction, returning the number of entries claimed and published.
+//
+// Publishing is bounded by the claim timeout: the claims of the entries
+// not processed in time are released, to be claimed again by the next batch.
+func (r *OutboxRelay) relayBatch(ctx context.Context) (claimed, relayed int, err error) {
+	entries, err := r.claimPendingEntries(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, r.claimTimeout)
+	defer cancel()
+
+	for i, entry := range entries {
+		if err := r.publish(publishCtx, entry); err != nil {
+			// NOTE: the claims must be released even if the context has been canceled.
+			releaseErr := r.releaseClaims(context.WithoutCancel(ctx), entries[i:])
+
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				return len(entries), relayed, releaseErr
+			}
+
+			return len(entries), relayed, errors.Join(err, releaseErr)
+		}
+
+		relayed++
+	}
+
+	return len(entries), relayed, nil
+}
+
+func (r *OutboxRelay) claimPendingEntries(ctx context.Context) ([]outboxEntry, error) {
+	var entries []outboxEntry
+
+	txOpts := pgx.TxOptions{ //nolint:exhaustruct // We don't need all fields.
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	}
+
+	if err := internal.RunTransaction(ctx, r.conn, txOpts, func(ctx context.Context, tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, lockOutboxQuery, r.tableName); err != nil {
+			return fmt.Errorf("postgres.OutboxRelay: failed to acquire outbox lock, %w", err)
+		}
+
+		rows, err := tx.Query(ctx,
+			fmt.Sprintf(claimPendingOutboxQueryTemplate, r.tableName),
+			r.batchSize, r.claimTimeout,
+		)
+		if err != nil {
+			return fmt.Errorf("postgres.OutboxRelay: failed to claim outbox entries, %w", err)
+		}
+
+		entries, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (outboxEntry, error) {
+			var entry outboxEntry
+
+			err := row.Scan(
+				&entry.id, &entry.streamID, &entry.eventType, &entry.version,
+				&entry.position, &entry.rawEvent, &entry.rawMetadata,
+			)
+
+			return entry, err //nolint:wrapcheck // Wrapped below.
+		})
+		if err != nil {
+			return fmt.Errorf("postgres.OutboxRelay: failed to scan outbox entries, %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("postgres.OutboxRelay: failed to relay outbox entries, %w", err)
+	}
+
+	// NOTE: UPDATE ... RETURNING does not guarantee any ordering.
+	slices.SortFunc(entries, func(a, b outboxEntry) int { return cmp.Compare(a.id, b.id) })
+
+	return entries, nil
+}
+
+func (r *OutboxRelay) releaseClaims(ctx context.Context, entries []outboxEntry) error {
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.id)
+	}
+
+	if _, err := r.conn.Exec(ctx, fmt.Sprintf(releaseOutboxClaimsQueryTemplate, r.tableName), ids); err != nil {
+		return fmt.Errorf("postgres.OutboxRelay: failed to release outbox claims, %w", err)
+	}
+
+	return nil
+}
+
+// publish publishes the outbox entry, retrying failed attempts with an exponential backoff.
+//
+// An error is returned only if the context is done, or if the outcome
+// of the entry could not be recorded: entries that cannot be published,
+// or deserialized, are marked as failed instead.
+func (r *OutboxRelay) publish(ctx context.Context, entry outboxEntry) error {
+	evt, err := r.deserialize(entry)
+	if err != nil {
+		return r.markFailed(ctx, entry, err)
+	}
+
+	backoff := r.initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		publishErr := r.publisher.Publish(ctx, evt)
+		if publishErr == nil {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("postgres.OutboxRelay: relay stopped, %w", err)
+		}
+
+		if _, err := r.conn.Exec(
+			ctx,
+			fmt.Sprintf(markOutboxAttemptFailedQueryTemplate, r.tableName),
+			entry.id, publishErr.Error(),
+		); err != nil {
+			return fmt.Errorf("postgres.OutboxRelay: failed to record failed attempt, %w", err)
+		}
+
+		if attempt >= r.maxAttempts {
+			return r.markFailed(ctx, entry, publishErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("postgres.OutboxRelay: relay stopped, %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, r.maxBackoff)
+	}
+
+	if _, err := r.conn.Exec(ctx, fmt.Sprintf(markOutboxDeliveredQueryTemplate, r.tableName), entry.id); err != nil {
+		return fmt.Errorf("postgres.OutboxRelay: failed to mark outbox entry as delivered, %w", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) markFailed(ctx context.Context, entry outboxEntry, cause error) error {
+	if _, err := r.conn.Exec(
+		ctx,
+		fmt.Sprintf(markOutboxFailedQueryTemplate, r.tableName),
+		entry.id, cause.Error(),
+	); err != nil {
+		return fmt.Errorf("postgres.OutboxRelay: failed to mark outbox entry %d as failed, %w", entry.id, err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) deserialize(entry outboxEntry) (event.Persisted, error) {
+	var zeroValue event.Persisted
+
+	var metadata message.Metadata
+	if err := json.Unmarshal(entry.rawMetadata, &metadata); err != nil {
+		return zeroValue, fmt.Errorf("postgres.OutboxRelay: failed to deserialize metadata, %w", err)
+	}
+
+	rawEvent := entry.rawEvent
+
+	if r.upcasters != nil {
+		var err error
+		if rawEvent, metadata, err = r.upcasters.Upcast(entry.eventType, metadata, rawEvent); err != nil {
+			return zeroValue, fmt.Errorf("postgres.OutboxRelay: failed to upcast event, %w", err)
+		}
+	}
+
+	msg, err := serde.DeserializeNamed(r.messageSerde, entry.eventType, rawEvent)
+	if err != nil {
+		return zeroValue, fmt.Errorf("postgres.OutboxRelay: failed to deserialize event, %w", err)
+	}
+
+	return event.Persisted{
+		StreamID: entry.streamID,
+		Version:  entry.version,
+		Position: entry.position,
+		Envelope: event.Envelope{
+			Message:  msg,
+			Metadata: metadata,
+		},
+	}, nil
+}
@@ -0,0 +1,226 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
+	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	messageSerde := serde.Chain(
+		user.EventProtoSerde,
+		serde.NewProtoJSON(func() *userv1.Event { return new(userv1.Event) }),
+	)
+
+	repository := postgres.NewAggregateRepository(
+		conn, user.Type,
+		serde.Chain(
+			user.ProtoSerde,
+			serde.NewProtoJSON(func() *userv1.User { return new(userv1.User) }),
+		),
+		messageSerde,
+		postgres.WithOutbox[uuid.UUID, *user.User](postgres.DefaultOutboxTableName),
+	)
+
+	user.AggregateRepositorySuite(repository)(t)
+
+	// Drain the outbox entries written by the test suite.
+	drain := postgres.NewOutboxRelay(conn, messageSerde, event.PublisherFunc(func(context.Context, event.Persisted) error {
+		return nil
+	}))
+
+	for {
+		relayed, err := drain.RelayPending(ctx)
+		require.NoError(t, err)
+
+		if relayed == 0 {
+			break
+		}
+	}
+
+	t.Run("saved domain events are published in order, retrying failures", func(t *testing.T) {
+		id := uuid.New()
+
+		usr, err := user.Create(id, "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repository.Save(ctx, usr))
+
+		require.NoError(t, usr.UpdateEmail("john.doe@mail.com", time.Now(), nil))
+		require.NoError(t, repository.Save(ctx, usr))
+
+		var (
+			published []event.Persisted
+			attempts  int
+		)
+
+		relay := postgres.NewOutboxRelay(conn, messageSerde,
+			event.PublisherFunc(func(_ context.Context, evt event.Persisted) error {
+				if attempts++; attempts == 1 {
+					return errors.New("broker unavailable")
+				}
+
+				published = append(published, evt)
+
+				return nil
+			}),
+			postgres.WithOutboxRelayBackoff(time.Millisecond, 10*time.Millisecond),
+		)
+
+		relayed, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, relayed)
+
+		require.Len(t, published, 2)
+		assert.Equal(t, event.StreamID(id.String()), published[0].StreamID)
+		assert.Equal(t, version.Version(1), published[0].Version)
+		assert.Equal(t, version.Version(2), published[1].Version)
+		assert.Less(t, published[0].Position, published[1].Position)
+		assert.Equal(t, "UserEmailWasUpdated", published[1].Message.Name())
+
+		// Delivered entries are not published again.
+		relayed, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, relayed)
+	})
+
+	t.Run("entries failing to be published are set aside", func(t *testing.T) {
+		poison, err := user.Create(uuid.New(), "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repository.Save(ctx, poison))
+
+		usr, err := user.Create(uuid.New(), "Jane", "Doe", "jane@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repository.Save(ctx, usr))
+
+		errPublish := errors.New("broker unavailable")
+
+		var (
+			published []event.Persisted
+			attempts  int
+		)
+
+		relay := postgres.NewOutboxRelay(conn, messageSerde,
+			event.PublisherFunc(func(_ context.Context, evt event.Persisted) error {
+				if evt.StreamID == event.StreamID(poison.AggregateID().String()) {
+					attempts++
+
+					return errPublish
+				}
+
+				published = append(published, evt)
+
+				return nil
+			}),
+			postgres.WithOutboxRelayMaxAttempts(3),
+			postgres.WithOutboxRelayBackoff(time.Millisecond, time.Millisecond),
+		)
+
+		relayed, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+		assert.Equal(t, 3, attempts)
+
+		// The entries behind the failed one are published anyway.
+		require.Len(t, published, 1)
+		assert.Equal(t, event.StreamID(usr.AggregateID().String()), published[0].StreamID)
+
+		var (
+			recordedAttempts int
+			lastError        string
+		)
+
+		require.NoError(t, conn.QueryRow(
+			ctx,
+			`SELECT attempts, last_error FROM outbox WHERE failed_at IS NOT NULL`,
+		).Scan(&recordedAttempts, &lastError))
+
+		assert.Equal(t, 3, recordedAttempts)
+		assert.Equal(t, errPublish.Error(), lastError)
+
+		// Failed entries are not published again, unless requeued.
+		relayed, err = drain.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, relayed)
+
+		requeued, err := relay.RequeueFailed(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), requeued)
+
+		relayed, err = drain.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+	})
+
+	t.Run("domain events are upcast before being published", func(t *testing.T) {
+		usr, err := user.Create(uuid.New(), "John", "Doe", "john@doe.com", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repository.Save(ctx, usr))
+
+		upcasters := event.NewUpcasterRegistry()
+		require.NoError(t, upcasters.Register("UserWasCreated", 1, event.UpcasterFunc(func(data []byte) ([]byte, error) {
+			return bytes.ReplaceAll(data, []byte(`"John"`), []byte(`"Johnny"`)), nil
+		})))
+
+		var published []event.Persisted
+
+		relay := postgres.NewOutboxRelay(conn, messageSerde,
+			event.PublisherFunc(func(_ context.Context, evt event.Persisted) error {
+				published = append(published, evt)
+
+				return nil
+			}),
+			postgres.WithOutboxRelayUpcasters(upcasters),
+		)
+
+		relayed, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, relayed)
+		require.Len(t, published, 1)
+
+		evt, ok := published[0].Message.(*user.Event)
+		require.True(t, ok)
+
+		wasCreated, ok := evt.Kind.(*user.WasCreated)
+		require.True(t, ok)
+
+		assert.Equal(t, "Johnny", wasCreated.FirstName)
+	})
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/event"
+)
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ aggregate.SnapshotStore = SnapshotStore{}
+
+// SnapshotStore is an aggregate.SnapshotStore implementation targeted
+// to PostgreSQL databases.
+//
+// The implementation uses the "snapshots" table as its operational table
+// (see WithSnapshotsTableName to use a different one),
+// and keeps only the latest Snapshot for each Event Stream.
+type SnapshotStore struct {
+	conn      *pgxpool.Pool
+	tableName string
+}
+
+// NewSnapshotStore returns a new SnapshotStore instance.
+func NewSnapshotStore(conn *pgxpool.Pool, options ...Option[*SnapshotStore]) SnapshotStore {
+	store := SnapshotStore{
+		conn:      conn,
+		tableName: DefaultSnapshotsTableName,
+	}
+
+	for _, opt := range options {
+		opt.apply(&store)
+	}
+
+	return store
+}
+
+const (
+	latestSnapshotQueryTemplate = `
+		SELECT "version", "state", recorded_at
+		FROM %s
+		WHERE event_stream_id = $1
+	`
+
+	saveSnapshotQueryTemplate = `
+		INSERT INTO %[1]s (event_stream_id, "version", "state", recorded_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_stream_id) DO
+		UPDATE SET "version" = $2, "state" = $3, recorded_at = $4
+		WHERE %[1]s."version" < $2
+	`
+)
+
+// LatestSnapshot implements the aggregate.SnapshotStore interface.
+func (s SnapshotStore) LatestSnapshot(ctx context.Context, id event.StreamID) (aggregate.Snapshot, error) {
+	row := s.conn.QueryRow(ctx, fmt.Sprintf(latestSnapshotQueryTemplate, s.tableName), id)
+
+	var snapshot aggregate.Snapshot
+
+	if err := row.Scan(&snapshot.Version, &snapshot.State, &snapshot.RecordedAt); errors.Is(err, pgx.ErrNoRows) {
+		return aggregate.Snapshot{}, aggregate.ErrSnapshotNotFound
+	} else if err != nil {
+		return aggregate.Snapshot{}, fmt.Errorf("postgres.SnapshotStore: failed to fetch latest snapshot, %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// SaveSnapshot implements the aggregate.SnapshotStore interface.
+func (s SnapshotStore) SaveSnapshot(ctx context.Context, id event.StreamID, snapshot aggregate.Snapshot) error {
+	if _, err := s.conn.Exec(
+		ctx,
+		fmt.Sprintf(saveSnapshotQueryTemplate, s.tableName),
+		id, snapshot.Version, snapshot.State, snapshot.RecordedAt,
+	); err != nil {
+		return fmt.Errorf("postgres.SnapshotStore: failed to save snapshot, %w", err)
+	}
+
+	return nil
+}
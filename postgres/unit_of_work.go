@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/get-eventually/go-eventually/postgres/internal"
+)
+
+// UnitOfWork commits the changes performed by multiple components,
+// like different AggregateRepository instances, in a single transaction.
+//
+// Use NewUnitOfWork to create a new instance of this type.
+type UnitOfWork struct {
+	conn *pgxpool.Pool
+}
+
+// NewUnitOfWork returns a new UnitOfWork instance.
+//
+// The components taking part in the unit of work must use the same database.
+func NewUnitOfWork(conn *pgxpool.Pool) UnitOfWork {
+	return UnitOfWork{conn: conn}
+}
+
+// Run runs the provided function in a new transaction, carried by the context
+// passed to the function.
+//
+// The writes performed with that context by AggregateRepository.Save,
+// EventStore.Append, EventStore.AppendBatch and DeduplicationStore.RecordOutcome
+// participate in the transaction,
+// which is committed once the function returns with no error,
+// or rolled back otherwise.
+//
+// Reads, like AggregateRepository.Get, are performed outside of the transaction:
+// concurrent changes are still detected by the optimistic concurrency checks on Save.
+// Serialization failures are returned as a version.ConflictError, so that
+// the whole unit of work can be retried.
+//
+// Appending Domain Events acquires a transaction-level lock on the events table,
+// to keep the global log ordered: the lock is held until the function returns,
+// blocking the appends of all the other writers in the meantime.
+// Keep the function short, and never append events with a context not derived
+// from the one passed to the function: the append would run outside of the transaction,
+// waiting forever for the lock held by the unit of work itself.
+//
+// The transaction carried by the context must not be used concurrently:
+// the components taking part in the unit of work must be called sequentially,
+// and not from different goroutines.
+func (uow UnitOfWork) Run(ctx context.Context, do func(ctx context.Context) error) error {
+	txOpts := pgx.TxOptions{ //nolint:exhaustruct // We don't need all fields.
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	}
+
+	if err := internal.RunTransaction(ctx, uow.conn, txOpts, func(ctx context.Context, tx pgx.Tx) error {
+		return do(internal.ContextWithTx(ctx, tx))
+	}); err != nil {
+		return fmt.Errorf("postgres.UnitOfWork: failed to run unit of work, %w", conflictOnSerializationFailure(err, nil))
+	}
+
+	return nil
+}
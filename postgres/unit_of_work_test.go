@@ -0,0 +1,203 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib" // Used to bring in the driver for sql.Open.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/internal/user"
+	userv1 "github.com/get-eventually/go-eventually/internal/user/gen/user/v1"
+	"github.com/get-eventually/go-eventually/postgres"
+	"github.com/get-eventually/go-eventually/postgres/internal"
+	"github.com/get-eventually/go-eventually/serde"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ctx := context.Background()
+
+	container, err := internal.NewPostgresContainer(ctx)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, container.Terminate(ctx))
+	}()
+
+	db, err := sql.Open("pgx", container.ConnectionDSN)
+	require.NoError(t, err)
+	require.NoError(t, postgres.RunMigrations(db))
+	require.NoError(t, db.Close())
+
+	conn, err := pgxpool.New(ctx, container.ConnectionDSN)
+	require.NoError(t, err)
+
+	messageSerde := serde.Chain(
+		user.EventProtoSerde,
+		serde.NewProtoJSON(func() *userv1.Event { return new(userv1.Event) }),
+	)
+
+	repository := postgres.NewAggregateRepository(
+		conn, user.Type,
+		serde.Chain(
+			user.ProtoSerde,
+			serde.NewProtoJSON(func() *userv1.User { return new(userv1.User) }),
+		),
+		messageSerde,
+	)
+
+	// The second repository manages a different Aggregate table,
+	// like the repository of another Aggregate type would.
+	_, err = conn.Exec(ctx, `CREATE TABLE secondary_aggregates (LIKE aggregates INCLUDING ALL)`)
+	require.NoError(t, err)
+
+	secondaryRepository := postgres.NewAggregateRepository(
+		conn, user.Type,
+		serde.Chain(
+			user.ProtoSerde,
+			serde.NewProtoJSON(func() *userv1.User { return new(userv1.User) }),
+		),
+		messageSerde,
+		postgres.WithAggregateTableName[uuid.UUID, *user.User]("secondary_aggregates"),
+	)
+
+	eventStore := postgres.NewEventStore(conn, messageSerde)
+	unitOfWork := postgres.NewUnitOfWork(conn)
+
+	// saveBoth saves a new User through the repository, and appends the events
+	// of another new User straight to the Event Store, in the same unit of work.
+	saveBoth := func(t *testing.T, result error) (uuid.UUID, uuid.UUID, error) {
+		t.Helper()
+
+		now := time.Now()
+		savedID, appendedID := uuid.New(), uuid.New()
+
+		saved, err := user.Create(savedID, "John", "Doe", "john@doe.com", now, now)
+		require.NoError(t, err)
+
+		appended, err := user.Create(appendedID, "Jane", "Doe", "jane@doe.com", now, now)
+		require.NoError(t, err)
+
+		err = unitOfWork.Run(ctx, func(ctx context.Context) error {
+			if err := repository.Save(ctx, saved); err != nil {
+				return err
+			}
+
+			if _, err := eventStore.Append(
+				ctx, event.StreamID(appendedID.String()), version.NoStream, appended.FlushRecordedEvents()...,
+			); err != nil {
+				return err
+			}
+
+			return result
+		})
+
+		return savedID, appendedID, err
+	}
+
+	// saveInBoth saves a new User through each repository, in the same unit of work.
+	saveInBoth := func(t *testing.T, result error) (uuid.UUID, uuid.UUID, error) {
+		t.Helper()
+
+		now := time.Now()
+		primaryID, secondaryID := uuid.New(), uuid.New()
+
+		primary, err := user.Create(primaryID, "John", "Doe", "john@doe.com", now, now)
+		require.NoError(t, err)
+
+		secondary, err := user.Create(secondaryID, "Jane", "Doe", "jane@doe.com", now, now)
+		require.NoError(t, err)
+
+		err = unitOfWork.Run(ctx, func(ctx context.Context) error {
+			if err := repository.Save(ctx, primary); err != nil {
+				return err
+			}
+
+			if err := secondaryRepository.Save(ctx, secondary); err != nil {
+				return err
+			}
+
+			return result
+		})
+
+		return primaryID, secondaryID, err
+	}
+
+	countEvents := func(t *testing.T, id uuid.UUID) int {
+		t.Helper()
+
+		stream := eventStore.Stream(ctx, event.StreamID(id.String()), version.SelectFromBeginning)
+
+		var count int
+		for range stream.Iter() {
+			count++
+		}
+
+		require.NoError(t, stream.Err())
+
+		return count
+	}
+
+	t.Run("changes are committed together", func(t *testing.T) {
+		savedID, appendedID, err := saveBoth(t, nil)
+		require.NoError(t, err)
+
+		_, err = repository.Get(ctx, savedID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, countEvents(t, savedID))
+		assert.Equal(t, 1, countEvents(t, appendedID))
+	})
+
+	t.Run("changes are rolled back together", func(t *testing.T) {
+		errAborted := errors.New("aborted")
+
+		savedID, appendedID, err := saveBoth(t, errAborted)
+		require.ErrorIs(t, err, errAborted)
+
+		_, err = repository.Get(ctx, savedID)
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+		assert.Equal(t, 0, countEvents(t, savedID))
+		assert.Equal(t, 0, countEvents(t, appendedID))
+	})
+	t.Run("changes of multiple repositories are committed together", func(t *testing.T) {
+		primaryID, secondaryID, err := saveInBoth(t, nil)
+		require.NoError(t, err)
+
+		_, err = repository.Get(ctx, primaryID)
+		require.NoError(t, err)
+
+		_, err = secondaryRepository.Get(ctx, secondaryID)
+		require.NoError(t, err)
+
+		_, err = repository.Get(ctx, secondaryID)
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+	})
+
+	t.Run("changes of multiple repositories are rolled back together", func(t *testing.T) {
+		errAborted := errors.New("aborted")
+
+		primaryID, secondaryID, err := saveInBoth(t, errAborted)
+		require.ErrorIs(t, err, errAborted)
+
+		_, err = repository.Get(ctx, primaryID)
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+
+		_, err = secondaryRepository.Get(ctx, secondaryID)
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+		assert.Equal(t, 0, countEvents(t, primaryID))
+		assert.Equal(t, 0, countEvents(t, secondaryID))
+	})
+}
@@ -0,0 +1,4 @@
+// Package projection contains components to run managed projections,
+// building read models from the global Event Store log through event.Processor,
+// that can be rebuilt from scratch while the current read model keeps serving queries.
+package projection
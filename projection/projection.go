@@ -0,0 +1,74 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/get-eventually/go-eventually/event"
+)
+
+// Generation identifies one of the read models built by a projection Target.
+//
+// Each rebuild of a projection builds a new Generation of its read model,
+// following the one currently serving queries.
+type Generation uint64
+
+// Target is the read model built by a projection.
+//
+// A Target keeps one or more Generations of its read model (e.g. one table
+// for each Generation), only one of which is active and serves queries.
+type Target interface {
+	// Active returns the Generation of the read model currently serving queries.
+	//
+	// A zero value should be returned if no Generation has ever been activated.
+	Active(ctx context.Context) (Generation, error)
+
+	// Processor returns the event.Processor building the read model
+	// of the specified Generation.
+	Processor(generation Generation) event.Processor
+
+	// Reset clears the read model of the specified Generation,
+	// before it gets rebuilt from the start of the Event Store log.
+	Reset(ctx context.Context, generation Generation) error
+
+	// Swap atomically makes the read model of the specified Generation
+	// the one serving queries.
+	Swap(ctx context.Context, generation Generation) error
+}
+
+// State is the state of a projection Generation managed by a Runner.
+type State string
+
+const (
+	// StateStopped is the state of a Generation that is not running.
+	StateStopped State = "stopped"
+	// StateCatchingUp is the state of a Generation processing the Domain Events
+	// in the Event Store log, that has not reached the end of the log yet.
+	StateCatchingUp State = "catching-up"
+	// StateLive is the state of a Generation that has reached the end
+	// of the Event Store log, and processes new Domain Events as they are committed.
+	StateLive State = "live"
+	// StateFailed is the state of a Generation that failed processing.
+	StateFailed State = "failed"
+)
+
+// GenerationStatus is the status of a projection Generation managed by a Runner.
+type GenerationStatus struct {
+	Generation Generation
+	State      State
+	// Position is the position of the last Domain Event processed.
+	Position event.Position
+	// Lag is the number of positions between Position and the head
+	// of the Event Store log, as read by the Runner every poll interval.
+	Lag uint64
+	// Err is the processing error, if the Generation failed.
+	Err error
+}
+
+// Status is the status of a projection managed by a Runner.
+type Status struct {
+	Name string
+	// Active is the status of the Generation serving queries.
+	Active GenerationStatus
+	// Rebuild is the status of the Generation being rebuilt, if any.
+	Rebuild *GenerationStatus
+}
@@ -0,0 +1,528 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/subscription"
+)
+
+// DefaultPollInterval is the default interval used by a Runner to poll
+// the Event Store for new Domain Events, once a projection has caught up with the log.
+const DefaultPollInterval = 500 * time.Millisecond
+
+var (
+	// ErrProjectionNotFound is returned by Runner when the named projection
+	// has not been registered.
+	ErrProjectionNotFound = errors.New("projection.Runner: projection not found")
+
+	// ErrProjectionAlreadyRegistered is returned by Runner.Register when
+	// a projection with the same name has already been registered.
+	ErrProjectionAlreadyRegistered = errors.New("projection.Runner: projection already registered")
+
+	// ErrRunnerRunning is returned by Runner when performing an operation
+	// that is only allowed while the Runner is not running.
+	ErrRunnerRunning = errors.New("projection.Runner: runner is running")
+
+	// ErrRunnerNotRunning is returned by Runner when performing an operation
+	// that is only allowed while the Runner is running.
+	ErrRunnerNotRunning = errors.New("projection.Runner: runner is not running")
+
+	// ErrRebuildInProgress is returned by Runner.Rebuild when the projection
+	// is already being rebuilt.
+	ErrRebuildInProgress = errors.New("projection.Runner: rebuild already in progress")
+)
+
+// Option can be used to change the configuration of a Runner.
+type Option interface {
+	apply(*Runner)
+}
+
+type option func(*Runner)
+
+func (apply option) apply(r *Runner) { apply(r) }
+
+// WithPollInterval specifies the interval used by the Runner to poll
+// the Event Store for new Domain Events, once a projection has caught up.
+func WithPollInterval(interval time.Duration) Option {
+	return option(func(r *Runner) {
+		r.pollInterval = interval
+	})
+}
+
+// CheckpointName returns the name used by a Runner to store the checkpoint
+// of the specified projection Generation in its subscription.Checkpointer.
+func CheckpointName(name string, generation Generation) string {
+	return name + "/" + strconv.FormatUint(uint64(generation), 10)
+}
+
+// Runner runs named projections, feeding the event.Processor of their
+// active Target Generation with the Domain Events from the global Event Store log.
+//
+// Like subscription.CatchUp, Domain Events are processed in order with
+// at-least-once semantics, using a subscription.Checkpointer to keep track
+// of the position of each Generation (see CheckpointName).
+//
+// A projection can be rebuilt from the start of the log using Rebuild: the new
+// Generation is built in the background while the active one keeps serving queries,
+// and it is atomically swapped in once it has caught up with the log.
+// Rebuilds are not resumed if the Runner is restarted in the meantime.
+//
+// Use Status to know the position, lag and state of a projection and its rebuild.
+// The lag is measured against the head of the log, read through event.HeadReader
+// when the Event Store implements it.
+type Runner struct {
+	eventStore   event.AllStreamer
+	checkpointer subscription.Checkpointer
+	pollInterval time.Duration
+
+	mx          sync.Mutex
+	projections map[string]*managedProjection
+	running     *run
+}
+
+type run struct {
+	ctx  context.Context // NOTE: used to start rebuilds while running.
+	wg   sync.WaitGroup
+	errs chan error
+}
+
+type managedProjection struct {
+	name   string
+	target Target
+
+	// NOTE: the latest position of the Event Store log observed by the projection workers.
+	head atomic.Uint64
+
+	mx      sync.Mutex
+	active  *worker
+	rebuild *worker
+}
+
+type worker struct {
+	generation Generation
+	cancel     context.CancelFunc
+	position   atomic.Uint64
+
+	// NOTE: protected by the managedProjection mutex.
+	state State
+	err   error
+}
+
+// NewRunner returns a new Runner instance, running projections over the Domain Events
+// coming from the Event Store, and storing their checkpoints through the provided Checkpointer.
+func NewRunner(eventStore event.AllStreamer, checkpointer subscription.Checkpointer, options ...Option) *Runner {
+	r := &Runner{
+		eventStore:   eventStore,
+		checkpointer: checkpointer,
+		pollInterval: DefaultPollInterval,
+		mx:           sync.Mutex{},
+		projections:  make(map[string]*managedProjection),
+		running:      nil,
+	}
+
+	for _, opt := range options {
+		opt.apply(r)
+	}
+
+	return r
+}
+
+// Register registers a new projection, identified by the provided name,
+// that builds the specified Target.
+//
+// Projections must be registered before the Runner is started.
+func (r *Runner) Register(name string, target Target) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.running != nil {
+		return fmt.Errorf("projection.Runner: failed to register %q, %w", name, ErrRunnerRunning)
+	}
+
+	if _, ok := r.projections[name]; ok {
+		return fmt.Errorf("projection.Runner: failed to register %q, %w", name, ErrProjectionAlreadyRegistered)
+	}
+
+	r.projections[name] = &managedProjection{
+		name:    name,
+		target:  target,
+		head:    atomic.Uint64{},
+		mx:      sync.Mutex{},
+		active:  nil,
+		rebuild: nil,
+	}
+
+	return nil
+}
+
+// Run starts all the registered projections, blocking until the context is canceled
+// or the active Generation of a projection fails processing.
+//
+// When the context is canceled, Run returns the context error.
+// Failures of a rebuild do not stop the Runner, and are reported through Status instead.
+func (r *Runner) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	running := &run{
+		ctx:  ctx,
+		wg:   sync.WaitGroup{},
+		errs: make(chan error, 1),
+	}
+
+	r.mx.Lock()
+
+	if r.running != nil {
+		r.mx.Unlock()
+
+		return ErrRunnerRunning
+	}
+
+	r.running = running
+	projections := make([]*managedProjection, 0, len(r.projections))
+
+	for _, p := range r.projections {
+		projections = append(projections, p)
+	}
+
+	r.mx.Unlock()
+
+	defer func() {
+		cancel()
+		running.wg.Wait()
+
+		r.mx.Lock()
+		r.running = nil
+		r.mx.Unlock()
+	}()
+
+	for _, p := range projections {
+		generation, err := p.target.Active(ctx)
+		if err != nil {
+			return fmt.Errorf("projection.Runner: failed to get active generation of %q, %w", p.name, err)
+		}
+
+		p.mx.Lock()
+		p.active = r.start(running, p, newWorker(generation))
+		p.rebuild = nil
+		p.mx.Unlock()
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("projection.Runner: runner stopped, %w", ctx.Err())
+	case err := <-running.errs:
+		return err
+	}
+}
+
+// Rebuild starts rebuilding the named projection from the start of the Event Store log,
+// into the Generation following the active one, which is cleared first.
+//
+// Rebuild returns as soon as the rebuild has started: the active Generation keeps
+// serving queries until the new one catches up with the log, and then they are swapped.
+// Use Status to follow the rebuild progress.
+func (r *Runner) Rebuild(ctx context.Context, name string) error {
+	r.mx.Lock()
+	running, p := r.running, r.projections[name]
+	r.mx.Unlock()
+
+	if p == nil {
+		return fmt.Errorf("projection.Runner: failed to rebuild %q, %w", name, ErrProjectionNotFound)
+	}
+
+	p.mx.Lock()
+
+	if running == nil || running.ctx.Err() != nil || p.active == nil {
+		p.mx.Unlock()
+
+		return fmt.Errorf("projection.Runner: failed to rebuild %q, %w", name, ErrRunnerNotRunning)
+	}
+
+	if p.rebuild != nil && p.rebuild.state != StateFailed {
+		p.mx.Unlock()
+
+		return fmt.Errorf("projection.Runner: failed to rebuild %q, %w", name, ErrRebuildInProgress)
+	}
+
+	// NOTE: the rebuild worker is reserved before resetting the new Generation,
+	// so that the projection mutex is not held during I/O.
+	w := newWorker(p.active.generation + 1)
+	p.rebuild = w
+
+	p.mx.Unlock()
+
+	err := r.reset(ctx, p, w.generation)
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if err != nil {
+		w.state, w.err = StateFailed, err
+
+		return err
+	}
+
+	if p.rebuild != w || running.ctx.Err() != nil {
+		return fmt.Errorf("projection.Runner: failed to rebuild %q, %w", name, ErrRunnerNotRunning)
+	}
+
+	r.start(running, p, w)
+
+	return nil
+}
+
+// reset clears the specified Generation of the projection and its checkpoint,
+// to build it from the start of the log.
+func (r *Runner) reset(ctx context.Context, p *managedProjection, generation Generation) error {
+	if err := p.target.Reset(ctx, generation); err != nil {
+		return fmt.Errorf("projection.Runner: failed to reset generation %d of %q, %w", generation, p.name, err)
+	}
+
+	if err := r.checkpointer.Write(ctx, CheckpointName(p.name, generation), 0); err != nil {
+		return fmt.Errorf("projection.Runner: failed to reset checkpoint of %q, %w", p.name, err)
+	}
+
+	return nil
+}
+
+// Status returns the status of the named projection.
+func (r *Runner) Status(name string) (Status, error) {
+	r.mx.Lock()
+	p := r.projections[name]
+	r.mx.Unlock()
+
+	if p == nil {
+		return Status{}, fmt.Errorf("projection.Runner: failed to get status of %q, %w", name, ErrProjectionNotFound)
+	}
+
+	head := event.Position(p.head.Load())
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	status := Status{
+		Name:    name,
+		Active:  GenerationStatus{State: StateStopped}, //nolint:exhaustruct // Not running yet.
+		Rebuild: nil,
+	}
+
+	if p.active != nil {
+		status.Active = p.active.status(head)
+	}
+
+	if p.rebuild != nil {
+		rebuild := p.rebuild.status(head)
+		status.Rebuild = &rebuild
+	}
+
+	return status, nil
+}
+
+func (w *worker) status(head event.Position) GenerationStatus {
+	position := event.Position(w.position.Load())
+
+	var lag uint64
+	if head > position {
+		lag = uint64(head - position)
+	}
+
+	return GenerationStatus{
+		Generation: w.generation,
+		State:      w.state,
+		Position:   position,
+		Lag:        lag,
+		Err:        w.err,
+	}
+}
+
+func newWorker(generation Generation) *worker {
+	return &worker{
+		generation: generation,
+		cancel:     func() {},
+		position:   atomic.Uint64{},
+		state:      StateCatchingUp,
+		err:        nil,
+	}
+}
+
+// start runs the worker in the background, until the Runner is stopped
+// or the worker fails.
+//
+// NOTE: the projection mutex must be held by the caller.
+func (r *Runner) start(running *run, p *managedProjection, w *worker) *worker {
+	ctx, cancel := context.WithCancel(running.ctx)
+	w.cancel = cancel
+
+	running.wg.Go(func() {
+		defer cancel()
+
+		err := r.process(ctx, p, w)
+		r.stopped(ctx, running, p, w, err)
+	})
+
+	return w
+}
+
+func (r *Runner) process(ctx context.Context, p *managedProjection, w *worker) error {
+	checkpointName := CheckpointName(p.name, w.generation)
+
+	position, err := r.checkpointer.Read(ctx, checkpointName)
+	if err != nil {
+		return fmt.Errorf("projection.Runner: failed to read checkpoint for %q, %w", checkpointName, err)
+	}
+
+	p.advance(w, position)
+
+	sub := subscription.NewCatchUp(
+		checkpointName,
+		r.eventStore,
+		progressCheckpointer{Checkpointer: r.checkpointer, projection: p, worker: w},
+		p.target.Processor(w.generation),
+	)
+
+	for {
+		if err := r.refreshHead(ctx, p); err != nil {
+			return err
+		}
+
+		if position, err = sub.CatchUpFrom(ctx, position); err != nil {
+			return fmt.Errorf("projection.Runner: failed to catch up %q, %w", checkpointName, err)
+		}
+
+		if err := p.caughtUp(ctx, w); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("projection.Runner: projection %q stopped, %w", checkpointName, ctx.Err())
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// progressCheckpointer records the position processed by a worker
+// every time its checkpoint is written.
+type progressCheckpointer struct {
+	subscription.Checkpointer
+
+	projection *managedProjection
+	worker     *worker
+}
+
+// Write implements the subscription.Checkpointer interface.
+func (c progressCheckpointer) Write(ctx context.Context, name string, position event.Position) error {
+	if err := c.Checkpointer.Write(ctx, name, position); err != nil {
+		return err //nolint:wrapcheck // Already wrapped by subscription.CatchUp.
+	}
+
+	c.projection.advance(c.worker, position)
+
+	return nil
+}
+
+// refreshHead reads the latest position of the Event Store log, to report
+// the lag of the projection workers, using the event.HeadReader interface
+// if implemented and supported by the Event Store, or streaming the log past the known head otherwise.
+func (r *Runner) refreshHead(ctx context.Context, p *managedProjection) error {
+	if reader, ok := r.eventStore.(event.HeadReader); ok {
+		head, err := reader.Head(ctx)
+		if err == nil {
+			p.observe(head)
+
+			return nil
+		} else if !errors.Is(err, errors.ErrUnsupported) {
+			return fmt.Errorf("projection.Runner: failed to read head of the log for %q, %w", p.name, err)
+		}
+	}
+
+	var head event.Position
+
+	stream := r.eventStore.StreamAll(ctx, event.PositionSelector{From: event.Position(p.head.Load()) + 1})
+	for evt := range stream.Iter() {
+		head = evt.Position
+	}
+
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("projection.Runner: failed to read head of the log for %q, %w", p.name, err)
+	}
+
+	p.observe(head)
+
+	return nil
+}
+
+// advance records the position processed by the worker.
+func (p *managedProjection) advance(w *worker, position event.Position) {
+	w.position.Store(uint64(position))
+	p.observe(position)
+}
+
+// observe records the latest position of the Event Store log,
+// unless a later one has already been observed.
+func (p *managedProjection) observe(position event.Position) {
+	for {
+		head := p.head.Load()
+		if head >= uint64(position) || p.head.CompareAndSwap(head, uint64(position)) {
+			return
+		}
+	}
+}
+
+// caughtUp marks the worker as live, once it has reached the end of the log.
+//
+// If the worker is rebuilding the projection, its Generation is swapped in
+// to serve queries, and the previously active worker is stopped.
+func (p *managedProjection) caughtUp(ctx context.Context, w *worker) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	w.state = StateLive
+
+	if p.rebuild != w {
+		return nil
+	}
+
+	if err := p.target.Swap(ctx, w.generation); err != nil {
+		return fmt.Errorf("projection.Runner: failed to swap generation %d of %q, %w", w.generation, p.name, err)
+	}
+
+	p.active.cancel()
+	p.active = w
+	p.rebuild = nil
+
+	return nil
+}
+
+// stopped records the outcome of a worker that stopped running.
+//
+// Failures of the active worker are reported to the Runner.
+func (r *Runner) stopped(ctx context.Context, running *run, p *managedProjection, w *worker, err error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if ctx.Err() != nil {
+		w.state = StateStopped
+
+		return
+	}
+
+	w.state, w.err = StateFailed, err
+
+	if p.active != w {
+		return
+	}
+
+	select {
+	case running.errs <- err:
+	default: // Another failure has already been reported.
+	}
+}
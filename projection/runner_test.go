@@ -0,0 +1,408 @@
+package projection_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/projection"
+	"github.com/get-eventually/go-eventually/subscription"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+type noopMessage struct{ id int }
+
+func (noopMessage) Name() string { return "noop" }
+
+const (
+	testStreamID       event.StreamID = "stream"
+	testProjectionName                = "test-projection"
+	testPollInterval                  = 5 * time.Millisecond
+)
+
+var errProcessingFailed = errors.New("processing failed")
+
+// inMemoryTarget keeps the ids of the processed noopMessages for each Generation.
+type inMemoryTarget struct {
+	mx          sync.Mutex
+	active      projection.Generation
+	generations map[projection.Generation][]int
+	failing     map[projection.Generation]bool
+}
+
+func newInMemoryTarget() *inMemoryTarget {
+	return &inMemoryTarget{
+		generations: make(map[projection.Generation][]int),
+		failing:     make(map[projection.Generation]bool),
+	}
+}
+
+func (t *inMemoryTarget) Active(context.Context) (projection.Generation, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.active, nil
+}
+
+func (t *inMemoryTarget) Processor(generation projection.Generation) event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Persisted) error {
+		t.mx.Lock()
+		defer t.mx.Unlock()
+
+		if t.failing[generation] {
+			return errProcessingFailed
+		}
+
+		t.generations[generation] = append(t.generations[generation], evt.Message.(noopMessage).id) //nolint:errcheck,forcetypeassert // test helper
+
+		return nil
+	})
+}
+
+func (t *inMemoryTarget) Reset(_ context.Context, generation projection.Generation) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	delete(t.generations, generation)
+
+	return nil
+}
+
+func (t *inMemoryTarget) Swap(_ context.Context, generation projection.Generation) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.active = generation
+
+	return nil
+}
+
+func (t *inMemoryTarget) serving() []int {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return append([]int(nil), t.generations[t.active]...)
+}
+
+func (t *inMemoryTarget) failGeneration(generation projection.Generation) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.failing[generation] = true
+}
+
+// blockingTarget is an inMemoryTarget whose processors wait to be released
+// before processing the noopMessage with the specified id.
+type blockingTarget struct {
+	*inMemoryTarget
+
+	blockAt int
+	release chan struct{}
+}
+
+func (t *blockingTarget) Processor(generation projection.Generation) event.Processor {
+	processor := t.inMemoryTarget.Processor(generation)
+
+	return event.ProcessorFunc(func(ctx context.Context, evt event.Persisted) error {
+		if evt.Message.(noopMessage).id == t.blockAt { //nolint:errcheck,forcetypeassert // test helper
+			select {
+			case <-t.release:
+			case <-ctx.Done():
+				return fmt.Errorf("blockingTarget: processor stopped, %w", ctx.Err())
+			}
+		}
+
+		return processor.Process(ctx, evt)
+	})
+}
+
+// resetBlockingTarget is an inMemoryTarget whose Reset waits to be released.
+type resetBlockingTarget struct {
+	*inMemoryTarget
+
+	resetting chan struct{}
+	release   chan struct{}
+}
+
+func (t *resetBlockingTarget) Reset(ctx context.Context, generation projection.Generation) error {
+	close(t.resetting)
+
+	select {
+	case <-t.release:
+	case <-ctx.Done():
+		return fmt.Errorf("resetBlockingTarget: reset stopped, %w", ctx.Err())
+	}
+
+	return t.inMemoryTarget.Reset(ctx, generation)
+}
+
+func appendIDs(t *testing.T, store event.Appender, ids ...int) {
+	t.Helper()
+
+	for _, id := range ids {
+		_, err := store.Append(t.Context(), testStreamID, version.Any, event.ToEnvelope(noopMessage{id: id}))
+		require.NoError(t, err)
+	}
+}
+
+func startRunner(t *testing.T, runner *projection.Runner) (context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errs := make(chan error, 1)
+
+	go func() { errs <- runner.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Active.State == projection.StateLive
+	}, time.Second, testPollInterval)
+
+	return cancel, errs
+}
+
+func TestRunner_RunsTheActiveGeneration(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3)
+
+	target := newInMemoryTarget()
+	runner := projection.NewRunner(store, subscription.NewInMemoryCheckpointer(),
+		projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+	require.ErrorIs(t, runner.Register(testProjectionName, target), projection.ErrProjectionAlreadyRegistered)
+
+	cancel, errs := startRunner(t, runner)
+
+	assert.Equal(t, []int{1, 2, 3}, target.serving())
+
+	appendIDs(t, store, 4)
+
+	require.Eventually(t, func() bool {
+		return len(target.serving()) == 4
+	}, time.Second, testPollInterval)
+
+	status, err := runner.Status(testProjectionName)
+	require.NoError(t, err)
+	assert.Equal(t, projection.GenerationStatus{
+		Generation: 0,
+		State:      projection.StateLive,
+		Position:   4,
+		Lag:        0,
+		Err:        nil,
+	}, status.Active)
+	assert.Nil(t, status.Rebuild)
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+
+	_, err = runner.Status("missing")
+	require.ErrorIs(t, err, projection.ErrProjectionNotFound)
+}
+
+// unsupportedHeadStore is an event.InMemoryStore wrapper that does not support
+// reading the head of the log, like wrappers of Event Stores not implementing event.HeadReader.
+type unsupportedHeadStore struct {
+	*event.InMemoryStore
+}
+
+func (unsupportedHeadStore) Head(context.Context) (event.Position, error) {
+	return 0, fmt.Errorf("unsupportedHeadStore: head not supported, %w", errors.ErrUnsupported)
+}
+
+func TestRunner_ReportsTheLagOfTheActiveGeneration(t *testing.T) {
+	t.Run("with the head read from the event store", func(t *testing.T) {
+		testRunnerReportsTheLag(t, event.NewInMemoryStore())
+	})
+
+	t.Run("with the head read by streaming the log", func(t *testing.T) {
+		testRunnerReportsTheLag(t, unsupportedHeadStore{InMemoryStore: event.NewInMemoryStore()})
+	})
+}
+
+type appendAllStreamer interface {
+	event.Appender
+	event.AllStreamer
+}
+
+func testRunnerReportsTheLag(t *testing.T, store appendAllStreamer) {
+	t.Helper()
+
+	appendIDs(t, store, 1, 2, 3)
+
+	target := &blockingTarget{inMemoryTarget: newInMemoryTarget(), blockAt: 2, release: make(chan struct{})}
+	runner := projection.NewRunner(store, subscription.NewInMemoryCheckpointer(),
+		projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errs := make(chan error, 1)
+
+	go func() { errs <- runner.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Active.Position == 1 && status.Active.Lag == 2
+	}, time.Second, testPollInterval)
+
+	close(target.release)
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Active.State == projection.StateLive && status.Active.Position == 3 && status.Active.Lag == 0
+	}, time.Second, testPollInterval)
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestRunner_RebuildsAndSwapsGenerations(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3)
+
+	target := newInMemoryTarget()
+	checkpointer := subscription.NewInMemoryCheckpointer()
+	runner := projection.NewRunner(store, checkpointer, projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+	require.ErrorIs(t, runner.Rebuild(t.Context(), testProjectionName), projection.ErrRunnerNotRunning)
+
+	cancel, errs := startRunner(t, runner)
+	defer cancel()
+
+	require.NoError(t, runner.Rebuild(t.Context(), testProjectionName))
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Active.Generation == 1 && status.Rebuild == nil
+	}, time.Second, testPollInterval)
+
+	assert.Equal(t, []int{1, 2, 3}, target.serving())
+
+	appendIDs(t, store, 4)
+
+	require.Eventually(t, func() bool {
+		return len(target.serving()) == 4
+	}, time.Second, testPollInterval)
+
+	position, err := checkpointer.Read(t.Context(), projection.CheckpointName(testProjectionName, 1))
+	require.NoError(t, err)
+	assert.Equal(t, event.Position(4), position)
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestRunner_RebuildDoesNotBlockStatusWhileResetting(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3)
+
+	target := &resetBlockingTarget{
+		inMemoryTarget: newInMemoryTarget(),
+		resetting:      make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+
+	runner := projection.NewRunner(store, subscription.NewInMemoryCheckpointer(),
+		projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+
+	cancel, errs := startRunner(t, runner)
+	defer cancel()
+
+	rebuildErrs := make(chan error, 1)
+
+	go func() { rebuildErrs <- runner.Rebuild(t.Context(), testProjectionName) }()
+
+	<-target.resetting
+
+	status, err := runner.Status(testProjectionName)
+	require.NoError(t, err)
+	require.NotNil(t, status.Rebuild)
+	assert.Equal(t, projection.Generation(1), status.Rebuild.Generation)
+	assert.Equal(t, projection.StateLive, status.Active.State)
+
+	require.ErrorIs(t, runner.Rebuild(t.Context(), testProjectionName), projection.ErrRebuildInProgress)
+
+	close(target.release)
+	require.NoError(t, <-rebuildErrs)
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Active.Generation == 1 && status.Rebuild == nil
+	}, time.Second, testPollInterval)
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestRunner_RebuildFailuresKeepTheActiveGeneration(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3)
+
+	target := newInMemoryTarget()
+	target.failGeneration(1)
+
+	runner := projection.NewRunner(store, subscription.NewInMemoryCheckpointer(),
+		projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+
+	cancel, errs := startRunner(t, runner)
+	defer cancel()
+
+	require.NoError(t, runner.Rebuild(t.Context(), testProjectionName))
+
+	require.Eventually(t, func() bool {
+		status, err := runner.Status(testProjectionName)
+		require.NoError(t, err)
+
+		return status.Rebuild != nil && status.Rebuild.State == projection.StateFailed
+	}, time.Second, testPollInterval)
+
+	status, err := runner.Status(testProjectionName)
+	require.NoError(t, err)
+	require.ErrorIs(t, status.Rebuild.Err, errProcessingFailed)
+	assert.Equal(t, projection.Generation(1), status.Rebuild.Generation)
+	assert.Equal(t, uint64(3), status.Rebuild.Lag)
+	assert.Equal(t, projection.Generation(0), status.Active.Generation)
+	assert.Equal(t, projection.StateLive, status.Active.State)
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestRunner_StopsOnActiveGenerationFailure(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1)
+
+	target := newInMemoryTarget()
+	target.failGeneration(0)
+
+	runner := projection.NewRunner(store, subscription.NewInMemoryCheckpointer(),
+		projection.WithPollInterval(testPollInterval))
+
+	require.NoError(t, runner.Register(testProjectionName, target))
+	require.ErrorIs(t, runner.Run(t.Context()), errProcessingFailed)
+}
@@ -0,0 +1,69 @@
+package query
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenericHandlerFunc is a functional Handler type that accepts any Query,
+// through a GenericEnvelope, and returns any result.
+type GenericHandlerFunc func(ctx context.Context, query GenericEnvelope) (any, error)
+
+// Middleware decorates a Query Handler with cross-cutting behavior,
+// such as logging, validation, authorization or panic recovery.
+//
+// Middlewares work on GenericEnvelope values, so that they can be written once
+// and applied to any Handler[T, R] using Decorate.
+type Middleware func(next GenericHandlerFunc) GenericHandlerFunc
+
+// Chain composes the provided Middlewares into a single one.
+//
+// Middlewares are applied in the order specified: the first Middleware
+// is the outermost one, which means it is the first to receive the Query.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next GenericHandlerFunc) GenericHandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+
+		return next
+	}
+}
+
+// Decorate returns a Handler that runs the provided Middlewares
+// before calling the specified Handler.
+//
+// Middlewares may change both the Query Envelope passed to the next one and
+// the result returned, as long as the Query type and the result type are preserved.
+func Decorate[T Query, R any](handler Handler[T, R], middlewares ...Middleware) Handler[T, R] {
+	next := Chain(middlewares...)(func(ctx context.Context, query GenericEnvelope) (any, error) {
+		envelope, ok := FromGenericEnvelope[T](query)
+		if !ok {
+			var expected T
+
+			return nil, fmt.Errorf("query.Decorate: unexpected query type %T, expected %T", query.Message, expected)
+		}
+
+		return handler.Handle(ctx, envelope)
+	})
+
+	return HandlerFunc[T, R](func(ctx context.Context, query Envelope[T]) (R, error) {
+		var zeroValue R
+
+		result, err := next(ctx, query.ToGenericEnvelope())
+		if err != nil {
+			return zeroValue, err
+		}
+
+		if result == nil {
+			return zeroValue, nil
+		}
+
+		r, ok := result.(R)
+		if !ok {
+			return zeroValue, fmt.Errorf("query.Decorate: unexpected result type %T, expected %T", result, zeroValue)
+		}
+
+		return r, nil
+	})
+}
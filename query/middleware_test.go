@@ -0,0 +1,105 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/query"
+)
+
+func TestDecorate(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+
+	record := func(name string) query.Middleware {
+		return func(next query.GenericHandlerFunc) query.GenericHandlerFunc {
+			return func(ctx context.Context, q query.GenericEnvelope) (any, error) {
+				calls = append(calls, name+":"+q.Message.Name())
+
+				return next(ctx, q)
+			}
+		}
+	}
+
+	chain := query.Chain(record("first"), record("second"))
+
+	t.Run("middlewares are called in order before the handler", func(t *testing.T) {
+		calls = nil
+
+		handler1 := query.Decorate(query.HandlerFunc[queryTest1, string](
+			func(context.Context, query.Envelope[queryTest1]) (string, error) {
+				return "result", nil
+			},
+		), chain)
+
+		handler2 := query.Decorate(query.HandlerFunc[queryTest2, int](
+			func(context.Context, query.Envelope[queryTest2]) (int, error) {
+				return 42, nil
+			},
+		), chain)
+
+		result1, err := handler1.Handle(ctx, query.ToEnvelope(queryTest1{}))
+		require.NoError(t, err)
+		assert.Equal(t, "result", result1)
+
+		result2, err := handler2.Handle(ctx, query.ToEnvelope(queryTest2{}))
+		require.NoError(t, err)
+		assert.Equal(t, 42, result2)
+
+		assert.Equal(t, []string{
+			"first:query_test_1", "second:query_test_1",
+			"first:query_test_2", "second:query_test_2",
+		}, calls)
+	})
+
+	t.Run("middlewares can handle errors returned by the handler", func(t *testing.T) {
+		errHandler := errors.New("handler failed")
+
+		fallback := func(next query.GenericHandlerFunc) query.GenericHandlerFunc {
+			return func(ctx context.Context, q query.GenericEnvelope) (any, error) {
+				result, err := next(ctx, q)
+				if errors.Is(err, errHandler) {
+					return "fallback", nil
+				}
+
+				return result, err
+			}
+		}
+
+		handler := query.Decorate(query.HandlerFunc[queryTest1, string](
+			func(context.Context, query.Envelope[queryTest1]) (string, error) {
+				return "", errHandler
+			},
+		), fallback)
+
+		result, err := handler.Handle(ctx, query.ToEnvelope(queryTest1{}))
+		require.NoError(t, err)
+		assert.Equal(t, "fallback", result)
+	})
+
+	t.Run("middlewares changing the result type make the handler fail", func(t *testing.T) {
+		replace := func(next query.GenericHandlerFunc) query.GenericHandlerFunc {
+			return func(ctx context.Context, q query.GenericEnvelope) (any, error) {
+				if _, err := next(ctx, q); err != nil {
+					return nil, err
+				}
+
+				return 42, nil
+			}
+		}
+
+		handler := query.Decorate(query.HandlerFunc[queryTest1, string](
+			func(context.Context, query.Envelope[queryTest1]) (string, error) {
+				return "result", nil
+			},
+		), replace)
+
+		_, err := handler.Handle(ctx, query.ToEnvelope(queryTest1{}))
+		assert.Error(t, err)
+	})
+}
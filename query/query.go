@@ -41,3 +41,31 @@ type HandlerFunc[T Query, R any] func(ctx context.Context, query Envelope[T]) (R
 func (f HandlerFunc[T, R]) Handle(ctx context.Context, query Envelope[T]) (R, error) {
 	return f(ctx, query)
 }
+
+// GenericEnvelope is a Query Envelope that depends solely on the Query interface,
+// not a specific generic Query type.
+type GenericEnvelope Envelope[Query]
+
+// ToGenericEnvelope returns a GenericEnvelope version of the current Envelope instance.
+func (query Envelope[T]) ToGenericEnvelope() GenericEnvelope {
+	return GenericEnvelope{
+		Message:  query.Message,
+		Metadata: query.Metadata,
+	}
+}
+
+// FromGenericEnvelope attempts to type-cast a GenericEnvelope instance into
+// a strongly-typed Query Envelope.
+//
+// A boolean guard is returned to signal whether the type-casting was successful
+// or not.
+func FromGenericEnvelope[T Query](query GenericEnvelope) (Envelope[T], bool) {
+	if v, ok := query.Message.(T); ok {
+		return Envelope[T]{
+			Message:  v,
+			Metadata: query.Metadata,
+		}, true
+	}
+
+	return Envelope[T]{}, false //nolint:exhaustruct // This is a zero value anyway.
+}
@@ -0,0 +1,30 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/get-eventually/go-eventually/query"
+)
+
+type queryTest1 struct{}
+
+func (queryTest1) Name() string { return "query_test_1" }
+
+type queryTest2 struct{}
+
+func (queryTest2) Name() string { return "query_test_2" }
+
+func TestGenericEnvelope(t *testing.T) {
+	q1 := query.ToEnvelope(queryTest1{})
+	genericQ1 := q1.ToGenericEnvelope()
+
+	v1, ok := query.FromGenericEnvelope[queryTest1](genericQ1)
+	assert.Equal(t, q1, v1)
+	assert.True(t, ok)
+
+	v2, ok := query.FromGenericEnvelope[queryTest2](genericQ1)
+	assert.Zero(t, v2)
+	assert.False(t, ok)
+}
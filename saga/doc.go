@@ -0,0 +1,4 @@
+// Package saga contains components to implement long-running workflows
+// with the Saga (or Process Manager) pattern: event-sourced coordinators
+// that react to Domain Events and dispatch Commands in response.
+package saga
@@ -0,0 +1,116 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/message"
+)
+
+// ErrIDMismatch is returned by Manager when the id of a saga instance, after handling
+// a Domain Event, does not match the id the Domain Event has been correlated to.
+//
+// This happens when a new saga instance returns Commands without recording
+// the Domain Event starting the saga, which sets its id.
+var ErrIDMismatch = errors.New("saga.Manager: saga instance id does not match the correlated id")
+
+// Manager is an event.Processor that drives the instances of a saga type,
+// persisted through an aggregate.Repository.
+//
+// For each Domain Event correlated to a saga instance, the Manager loads
+// the instance, lets the Saga handle the Domain Event, dispatches the resulting
+// Commands and saves the new state of the instance, recording that the Domain Event
+// has been handled (see Handled).
+//
+// The Domain Event is carried in the context as the cause of the Commands
+// dispatched and the Domain Events recorded (see message.ContextWithCause).
+//
+// Redelivered Domain Events, e.g. by a subscription.CatchUp restarting from
+// an older checkpoint, are ignored if they have already been handled by the
+// saga instance. Since Commands are dispatched before saving the saga instance,
+// they are dispatched with at-least-once semantics.
+type Manager[I aggregate.ID, T Root[I]] struct {
+	typ        aggregate.Type[I, T]
+	repository aggregate.Repository[I, T]
+	saga       Saga[I, T]
+	dispatcher command.Dispatcher
+}
+
+// NewManager returns a new Manager instance for the saga type,
+// dispatching the Commands produced by the Saga through the provided command.Dispatcher.
+func NewManager[I aggregate.ID, T Root[I]](
+	typ aggregate.Type[I, T],
+	repository aggregate.Repository[I, T],
+	saga Saga[I, T],
+	dispatcher command.Dispatcher,
+) Manager[I, T] {
+	return Manager[I, T]{
+		typ:        typ,
+		repository: repository,
+		saga:       saga,
+		dispatcher: dispatcher,
+	}
+}
+
+// Process implements the event.Processor interface.
+func (m Manager[I, T]) Process(ctx context.Context, evt event.Persisted) error {
+	id, ok, err := m.saga.Correlate(evt)
+	if err != nil {
+		return fmt.Errorf("saga.Manager: failed to correlate event, %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	ctx = message.ContextWithCause(ctx, evt.Metadata)
+
+	instance, err := m.repository.Get(ctx, id)
+	if errors.Is(err, aggregate.ErrRootNotFound) {
+		instance = m.typ.Factory()
+	} else if err != nil {
+		return fmt.Errorf("saga.Manager: failed to get %s saga %q, %w", m.typ.Name, id, err)
+	}
+
+	if instance.Version() > 0 && evt.Position <= instance.HandledPosition() {
+		return nil
+	}
+
+	previousVersion := instance.Version()
+
+	commands, err := m.saga.Handle(ctx, instance, evt)
+	if err != nil {
+		return fmt.Errorf("saga.Manager: %s saga %q failed to handle event, %w", m.typ.Name, id, err)
+	}
+
+	// NOTE: Domain Events ignored by the saga instance are not recorded,
+	// to avoid creating saga instances for uncorrelated Domain Events.
+	if instance.Version() == previousVersion && len(commands) == 0 {
+		return nil
+	}
+
+	// NOTE: aggregate.ID values are not necessarily comparable.
+	if instanceID := instance.AggregateID(); instanceID.String() != id.String() {
+		return fmt.Errorf("saga.Manager: %s saga %q handled event as %q, %w", m.typ.Name, id, instanceID, ErrIDMismatch)
+	}
+
+	for _, cmd := range commands {
+		if err := m.dispatcher.Dispatch(ctx, cmd); err != nil {
+			return fmt.Errorf("saga.Manager: %s saga %q failed to dispatch command, %w", m.typ.Name, id, err)
+		}
+	}
+
+	if err := aggregate.RecordThat[I](instance, event.ToEnvelope(Handled{Position: evt.Position})); err != nil {
+		return fmt.Errorf("saga.Manager: failed to record handled event, %w", err)
+	}
+
+	if err := m.repository.Save(ctx, instance); err != nil {
+		return fmt.Errorf("saga.Manager: failed to save %s saga %q, %w", m.typ.Name, id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package saga_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/saga"
+)
+
+const listIDMetadataKey = "Todo-List-ID"
+
+type listID string
+
+func (id listID) String() string { return string(id) }
+
+// itemWasAdded is the Domain Event the reminder saga reacts to.
+type itemWasAdded struct{ Item string }
+
+func (itemWasAdded) Name() string { return "ItemWasAdded" }
+
+// scheduleReminder is the Command dispatched by the reminder saga.
+type scheduleReminder struct{ Item string }
+
+func (scheduleReminder) Name() string { return "ScheduleReminder" }
+
+type reminderWasScheduled struct {
+	ListID listID
+	Item   string
+}
+
+func (reminderWasScheduled) Name() string { return "ReminderWasScheduled" }
+
+// reminders is a saga scheduling a reminder for each item added to a list.
+type reminders struct {
+	saga.BaseSaga
+
+	id    listID
+	items []string
+}
+
+var remindersType = aggregate.Type[listID, *reminders]{
+	Name:    "Reminders",
+	Factory: func() *reminders { return new(reminders) },
+}
+
+func (r *reminders) AggregateID() listID { return r.id }
+
+func (r *reminders) Apply(evt event.Event) error {
+	switch evt := evt.(type) {
+	case reminderWasScheduled:
+		r.id = evt.ListID
+		r.items = append(r.items, evt.Item)
+	case saga.Handled:
+		r.ApplyHandled(evt)
+	default:
+		return fmt.Errorf("reminders.Apply: unexpected event %T", evt)
+	}
+
+	return nil
+}
+
+type remindersSaga struct{}
+
+func (remindersSaga) Correlate(evt event.Persisted) (listID, bool, error) {
+	return saga.CorrelateByMetadata(listIDMetadataKey, func(value string) (listID, error) {
+		return listID(value), nil
+	})(evt)
+}
+
+func (remindersSaga) Handle(_ context.Context, r *reminders, evt event.Persisted) ([]command.GenericEnvelope, error) {
+	added, ok := evt.Message.(itemWasAdded)
+	if !ok {
+		return nil, nil
+	}
+
+	if err := aggregate.RecordThat[listID](r, event.ToEnvelope(reminderWasScheduled{
+		ListID: listID(evt.Metadata[listIDMetadataKey]),
+		Item:   added.Item,
+	})); err != nil {
+		return nil, err
+	}
+
+	return []command.GenericEnvelope{
+		command.ToEnvelope(scheduleReminder{Item: added.Item}).ToGenericEnvelope(),
+	}, nil
+}
+
+// commandsOnlySaga is a saga returning Commands without recording
+// the Domain Event starting a new saga instance.
+type commandsOnlySaga struct{ remindersSaga }
+
+func (commandsOnlySaga) Handle(context.Context, *reminders, event.Persisted) ([]command.GenericEnvelope, error) {
+	return []command.GenericEnvelope{
+		command.ToEnvelope(scheduleReminder{Item: "milk"}).ToGenericEnvelope(),
+	}, nil
+}
+
+func persisted(position event.Position, msg message.Message, list listID) event.Persisted {
+	var metadata message.Metadata
+	if list != "" {
+		metadata = message.Metadata{listIDMetadataKey: string(list)}
+	}
+
+	return event.Persisted{
+		StreamID: "todo-list",
+		Version:  1,
+		Position: position,
+		Envelope: event.Envelope{Message: msg, Metadata: metadata},
+	}
+}
+
+func TestManager(t *testing.T) {
+	ctx := t.Context()
+	repository := aggregate.NewEventSourcedRepository(event.NewInMemoryStore(), remindersType)
+
+	var dispatched []command.GenericEnvelope
+
+	manager := saga.NewManager(remindersType, repository, remindersSaga{},
+		command.DispatcherFunc(func(_ context.Context, cmd command.GenericEnvelope) error {
+			dispatched = append(dispatched, cmd)
+
+			return nil
+		}),
+	)
+
+	t.Run("uncorrelated events are ignored", func(t *testing.T) {
+		require.NoError(t, manager.Process(ctx, persisted(1, itemWasAdded{Item: "milk"}, "")))
+		assert.Empty(t, dispatched)
+	})
+
+	t.Run("ignored events do not start a saga", func(t *testing.T) {
+		require.NoError(t, manager.Process(ctx, persisted(2, scheduleReminder{Item: "milk"}, "list")))
+		assert.Empty(t, dispatched)
+
+		_, err := repository.Get(ctx, "list")
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+	})
+
+	t.Run("correlated events are handled and commands dispatched", func(t *testing.T) {
+		require.NoError(t, manager.Process(ctx, persisted(3, itemWasAdded{Item: "milk"}, "list")))
+		require.NoError(t, manager.Process(ctx, persisted(4, itemWasAdded{Item: "eggs"}, "list")))
+
+		assert.Equal(t, []command.GenericEnvelope{
+			command.ToEnvelope(scheduleReminder{Item: "milk"}).ToGenericEnvelope(),
+			command.ToEnvelope(scheduleReminder{Item: "eggs"}).ToGenericEnvelope(),
+		}, dispatched)
+
+		instance, err := repository.Get(ctx, "list")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"milk", "eggs"}, instance.items)
+		assert.Equal(t, event.Position(4), instance.HandledPosition())
+	})
+
+	t.Run("redelivered events are not handled again", func(t *testing.T) {
+		dispatched = nil
+
+		require.NoError(t, manager.Process(ctx, persisted(3, itemWasAdded{Item: "milk"}, "list")))
+		require.NoError(t, manager.Process(ctx, persisted(4, itemWasAdded{Item: "eggs"}, "list")))
+		assert.Empty(t, dispatched)
+
+		instance, err := repository.Get(ctx, "list")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"milk", "eggs"}, instance.items)
+	})
+
+	t.Run("new instances must record the event starting the saga", func(t *testing.T) {
+		dispatched = nil
+
+		manager := saga.NewManager(remindersType, repository, commandsOnlySaga{},
+			command.DispatcherFunc(func(_ context.Context, cmd command.GenericEnvelope) error {
+				dispatched = append(dispatched, cmd)
+
+				return nil
+			}),
+		)
+
+		err := manager.Process(ctx, persisted(5, itemWasAdded{Item: "milk"}, "other-list"))
+		require.ErrorIs(t, err, saga.ErrIDMismatch)
+		assert.Empty(t, dispatched)
+
+		_, err = repository.Get(ctx, "other-list")
+		require.ErrorIs(t, err, aggregate.ErrRootNotFound)
+	})
+}
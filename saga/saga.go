@@ -0,0 +1,97 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/get-eventually/go-eventually/aggregate"
+	"github.com/get-eventually/go-eventually/command"
+	"github.com/get-eventually/go-eventually/event"
+)
+
+// Handled is the Domain Event recorded by a Manager in a saga instance,
+// once a Domain Event at the specified global Position has been handled.
+//
+// Saga instances must apply it through BaseSaga.ApplyHandled,
+// to keep track of the handled Domain Events.
+type Handled struct {
+	Position event.Position
+}
+
+// Name implements message.Message.
+func (Handled) Name() string { return "SagaHandled" }
+
+// Root is the interface describing a saga instance, an event-sourced
+// aggregate.Root that keeps track of the Domain Events it has handled.
+//
+// Make sure your saga types embed the saga.BaseSaga type
+// to complete the implementation of this interface.
+type Root[I aggregate.ID] interface {
+	aggregate.Root[I]
+
+	// HandledPosition returns the global position of the last Domain Event
+	// handled by the saga instance.
+	HandledPosition() event.Position
+}
+
+// BaseSaga completes the saga.Root interface implementation
+// when embedded to a user-defined saga type, in place of aggregate.BaseRoot.
+type BaseSaga struct {
+	aggregate.BaseRoot
+
+	handledPosition event.Position
+}
+
+// HandledPosition returns the global position of the last Domain Event
+// handled by the saga instance.
+func (bs *BaseSaga) HandledPosition() event.Position { return bs.handledPosition }
+
+// ApplyHandled applies the Handled Domain Event to the saga instance.
+//
+// Call this method from the saga Apply method, when receiving a Handled Domain Event.
+func (bs *BaseSaga) ApplyHandled(evt Handled) {
+	bs.handledPosition = evt.Position
+}
+
+// Saga describes the behavior of a saga type.
+type Saga[I aggregate.ID, T Root[I]] interface {
+	// Correlate returns the id of the saga instance the Domain Event is correlated to.
+	//
+	// A false value is returned if the saga does not react to the Domain Event.
+	Correlate(evt event.Persisted) (I, bool, error)
+
+	// Handle reacts to the Domain Event, updating the state of the saga instance
+	// through aggregate.RecordThat, and returning the Commands to dispatch.
+	//
+	// If no saga instance exists with the correlated id, a new one is created
+	// using the aggregate.Type factory: Handle should record the Domain Event
+	// starting the saga, setting its id to the correlated one, or ignore the Domain Event.
+	// Otherwise, the Manager fails with ErrIDMismatch.
+	Handle(ctx context.Context, saga T, evt event.Persisted) ([]command.GenericEnvelope, error)
+}
+
+// CorrelateByMetadata returns a Correlate function that correlates Domain Events
+// to the saga instance whose id is found under the specified Metadata key,
+// parsed using the provided function.
+//
+// Domain Events without the Metadata key are not correlated to any saga instance.
+func CorrelateByMetadata[I aggregate.ID](
+	key string,
+	parse func(string) (I, error),
+) func(evt event.Persisted) (I, bool, error) {
+	return func(evt event.Persisted) (I, bool, error) {
+		var zeroValue I
+
+		value, ok := evt.Metadata[key]
+		if !ok {
+			return zeroValue, false, nil
+		}
+
+		id, err := parse(value)
+		if err != nil {
+			return zeroValue, false, fmt.Errorf("saga.CorrelateByMetadata: failed to parse %q, %w", key, err)
+		}
+
+		return id, true, nil
+	}
+}
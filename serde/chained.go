@@ -4,6 +4,9 @@ import (
 	"fmt"
 )
 
+//nolint:exhaustruct // Interface implementation assertion.
+var _ NamedDeserializer[any, any] = Chained[any, any, any]{}
+
 // Chained is a serde type that allows to chain two separate serdes,
 // to map from an Src to a Dst type, using a common supporting type in the middle (Mid).
 type Chained[Src any, Mid any, Dst any] struct {
@@ -30,14 +33,31 @@ func (s Chained[Src, Mid, Dst]) Serialize(src Src) (Dst, error) {
 
 // Deserialize implements the serde.Deserializer interface.
 func (s Chained[Src, Mid, Dst]) Deserialize(dst Dst) (Src, error) {
+	return s.deserialize(dst, s.second.Deserialize, s.first.Deserialize)
+}
+
+// DeserializeNamed implements the serde.NamedDeserializer interface,
+// forwarding the type name to both stages, if they implement it as well.
+func (s Chained[Src, Mid, Dst]) DeserializeNamed(name string, dst Dst) (Src, error) {
+	return s.deserialize(dst,
+		func(dst Dst) (Mid, error) { return DeserializeNamed(s.second, name, dst) },
+		func(mid Mid) (Src, error) { return DeserializeNamed(s.first, name, mid) },
+	)
+}
+
+func (s Chained[Src, Mid, Dst]) deserialize(
+	dst Dst,
+	second func(Dst) (Mid, error),
+	first func(Mid) (Src, error),
+) (Src, error) {
 	var zeroValue Src
 
-	mid, err := s.second.Deserialize(dst)
+	mid, err := second(dst)
 	if err != nil {
 		return zeroValue, fmt.Errorf("serde.Chained: second stage deserializer failed, %w", err)
 	}
 
-	src, err := s.first.Deserialize(mid)
+	src, err := first(mid)
 	if err != nil {
 		return zeroValue, fmt.Errorf("serde.Chained: first stage deserializer failed, %w", err)
 	}
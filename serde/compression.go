@@ -0,0 +1,178 @@
+package serde
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec identifies the compression algorithm used by Compression,
+// and it is written as the header byte of the serialized data.
+type Codec byte
+
+const (
+	// CodecNone marks data that has been left uncompressed,
+	// e.g. because its size was below the Compression threshold.
+	CodecNone Codec = iota
+	// CodecGzip marks data compressed using gzip.
+	CodecGzip
+	// CodecZstd marks data compressed using zstd.
+	CodecZstd
+)
+
+// maxCodec is the highest header byte value reserved for codecs.
+//
+// These values are never found as the first byte of Protobuf or JSON data,
+// which allows Compression to read data serialized before it was introduced.
+const maxCodec = 0x07
+
+// ErrUnsupportedCodec is returned by Compression when using an unknown Codec.
+var ErrUnsupportedCodec = errors.New("serde.Compression: unsupported codec")
+
+// NOTE: zstd encoders and decoders are safe for concurrent use, and expensive to create.
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) { return zstd.NewWriter(nil) })
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) { return zstd.NewReader(nil) })
+)
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ NamedDeserializer[[]byte, []byte] = Compression{}
+
+// Compression is a serde.Bytes[[]byte] implementation that compresses data
+// larger than a threshold using the specified Codec.
+//
+// The serialized data is prefixed with a header byte marking the Codec used,
+// so that data can always be deserialized, regardless of the Codec currently configured.
+// Data with no header byte, e.g. serialized before introducing Compression, is returned as-is.
+//
+// Use serde.Chain or serde.Compress to compress the output of another serde.
+type Compression struct {
+	codec     Codec
+	threshold int
+}
+
+// NewCompression returns a new Compression serde instance, compressing
+// data using the specified Codec when larger than the threshold, in bytes.
+func NewCompression(codec Codec, threshold int) Compression {
+	return Compression{
+		codec:     codec,
+		threshold: threshold,
+	}
+}
+
+// Compress decorates the provided serde to compress the data it produces,
+// using a Compression serde with the specified Codec and threshold.
+func Compress[T any](serde Bytes[T], codec Codec, threshold int) Chained[T, []byte, []byte] {
+	return Chain[T, []byte, []byte](serde, NewCompression(codec, threshold))
+}
+
+// Serialize implements the serde.Serializer interface.
+func (c Compression) Serialize(data []byte) ([]byte, error) {
+	codec := c.codec
+	if len(data) < c.threshold {
+		codec = CodecNone
+	}
+
+	compressed, err := compress(codec, data)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Compression: failed to compress data, %w", err)
+	}
+
+	return compressed, nil
+}
+
+// Deserialize implements the serde.Deserializer interface.
+func (c Compression) Deserialize(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] > maxCodec {
+		return data, nil
+	}
+
+	decompressed, err := decompress(Codec(data[0]), data[1:])
+	if err != nil {
+		return nil, fmt.Errorf("serde.Compression: failed to decompress data, %w", err)
+	}
+
+	return decompressed, nil
+}
+
+// DeserializeNamed implements the serde.NamedDeserializer interface.
+//
+// The type name plays no role in decompression, and is ignored:
+// this allows to use Compression at any stage of a Chained serde.
+func (c Compression) DeserializeNamed(_ string, data []byte) ([]byte, error) {
+	return c.Deserialize(data)
+}
+
+func compress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return append([]byte{byte(CodecNone)}, data...), nil
+
+	case CodecGzip:
+		buf := bytes.NewBuffer([]byte{byte(CodecGzip)})
+		w := gzip.NewWriter(buf)
+
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+
+		return buf.Bytes(), nil
+
+	case CodecZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+
+		return encoder.EncodeAll(data, []byte{byte(CodecZstd)}), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedCodec, codec)
+	}
+}
+
+func decompress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer r.Close() //nolint:errcheck,gosec // Reading errors are reported by io.ReadAll.
+
+		decompressed, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+
+		return decompressed, nil
+
+	case CodecZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+
+		decompressed, err := decoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+
+		return decompressed, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedCodec, codec)
+	}
+}
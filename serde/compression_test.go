@@ -0,0 +1,87 @@
+package serde_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/serde"
+)
+
+func TestCompression(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+	small := []byte(`{"key":"value"}`)
+
+	for _, codec := range []serde.Codec{serde.CodecNone, serde.CodecGzip, serde.CodecZstd} {
+		compression := serde.NewCompression(codec, 256)
+
+		t.Run(fmt.Sprintf("codec %d: data above the threshold is compressed", codec), func(t *testing.T) {
+			compressed, err := compression.Serialize(large)
+			require.NoError(t, err)
+			assert.Equal(t, byte(codec), compressed[0])
+
+			if codec != serde.CodecNone {
+				assert.Less(t, len(compressed), len(large))
+			}
+
+			decompressed, err := compression.Deserialize(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, large, decompressed)
+		})
+
+		t.Run(fmt.Sprintf("codec %d: data below the threshold is not compressed", codec), func(t *testing.T) {
+			serialized, err := compression.Serialize(small)
+			require.NoError(t, err)
+			assert.Equal(t, append([]byte{byte(serde.CodecNone)}, small...), serialized)
+
+			deserialized, err := compression.Deserialize(serialized)
+			require.NoError(t, err)
+			assert.Equal(t, small, deserialized)
+		})
+	}
+
+	t.Run("data compressed with a different codec can be read", func(t *testing.T) {
+		compressed, err := serde.NewCompression(serde.CodecGzip, 0).Serialize(large)
+		require.NoError(t, err)
+
+		decompressed, err := serde.NewCompression(serde.CodecZstd, 0).Deserialize(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, large, decompressed)
+	})
+
+	t.Run("data with no header is read as-is", func(t *testing.T) {
+		deserialized, err := serde.NewCompression(serde.CodecZstd, 0).Deserialize(small)
+		require.NoError(t, err)
+		assert.Equal(t, small, deserialized)
+	})
+
+	t.Run("serialize fails on unsupported codecs", func(t *testing.T) {
+		_, err := serde.NewCompression(serde.Codec(42), 0).Serialize(large)
+		assert.ErrorIs(t, err, serde.ErrUnsupportedCodec)
+	})
+
+	t.Run("deserialize fails on unsupported codecs", func(t *testing.T) {
+		_, err := serde.NewCompression(serde.CodecZstd, 0).Deserialize([]byte{0x07, 0x00})
+		assert.ErrorIs(t, err, serde.ErrUnsupportedCodec)
+	})
+}
+
+func TestCompress(t *testing.T) {
+	compressed := serde.Compress(
+		serde.NewJSON(func() *myJSONData { return new(myJSONData) }),
+		serde.CodecZstd, 0,
+	)
+
+	data := &myJSONData{Enum: enumFirstString, Something: 1, Else: "else"}
+
+	serialized, err := compressed.Serialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, byte(serde.CodecZstd), serialized[0])
+
+	deserialized, err := compressed.Deserialize(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, data, deserialized)
+}
@@ -0,0 +1,116 @@
+package serde
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// KeySize is the size in bytes of the encryption keys generated by the KeyStore
+// implementations in this library, suitable for AES-256.
+const KeySize = 32
+
+// ErrKeyNotFound is returned by KeyStore.Key when no key exists for a data subject,
+// either because it has never been created or because it has been deleted.
+var ErrKeyNotFound = errors.New("serde.KeyStore: key not found")
+
+// KeyStore stores the encryption keys used by Shredder to encrypt personal data,
+// one per data subject.
+//
+// Deleting the key of a data subject makes all its encrypted personal data
+// unreadable, a technique known as crypto-shredding.
+type KeyStore interface {
+	// Key returns the encryption key of the specified data subject.
+	//
+	// ErrKeyNotFound is returned if the key does not exist.
+	Key(ctx context.Context, subjectID string) ([]byte, error)
+
+	// CreateKey returns the encryption key of the specified data subject,
+	// generating a new one if it does not exist.
+	//
+	// ErrSubjectForgotten is returned if the key has been deleted.
+	CreateKey(ctx context.Context, subjectID string) ([]byte, error)
+
+	// DeleteKey deletes the encryption key of the specified data subject,
+	// remembering it has been forgotten: no new key can be created for it.
+	DeleteKey(ctx context.Context, subjectID string) error
+}
+
+// NewKey generates a new random encryption key of KeySize bytes.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("serde.NewKey: failed to generate key, %w", err)
+	}
+
+	return key, nil
+}
+
+// Interface implementation assertion.
+var _ KeyStore = new(InMemoryKeyStore)
+
+// InMemoryKeyStore is a thread-safe, in-memory KeyStore implementation.
+type InMemoryKeyStore struct {
+	mx        sync.RWMutex
+	keys      map[string][]byte
+	forgotten map[string]struct{}
+}
+
+// NewInMemoryKeyStore creates a new serde.InMemoryKeyStore instance.
+func NewInMemoryKeyStore() *InMemoryKeyStore {
+	return &InMemoryKeyStore{
+		mx:        sync.RWMutex{},
+		keys:      make(map[string][]byte),
+		forgotten: make(map[string]struct{}),
+	}
+}
+
+// Key implements the serde.KeyStore interface.
+func (s *InMemoryKeyStore) Key(_ context.Context, subjectID string) ([]byte, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	key, ok := s.keys[subjectID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// CreateKey implements the serde.KeyStore interface.
+func (s *InMemoryKeyStore) CreateKey(_ context.Context, subjectID string) ([]byte, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if key, ok := s.keys[subjectID]; ok {
+		return key, nil
+	}
+
+	if _, ok := s.forgotten[subjectID]; ok {
+		return nil, fmt.Errorf("serde.InMemoryKeyStore: failed to create key, %w", ErrSubjectForgotten)
+	}
+
+	key, err := NewKey()
+	if err != nil {
+		return nil, fmt.Errorf("serde.InMemoryKeyStore: failed to create key, %w", err)
+	}
+
+	s.keys[subjectID] = key
+
+	return key, nil
+}
+
+// DeleteKey implements the serde.KeyStore interface.
+func (s *InMemoryKeyStore) DeleteKey(_ context.Context, subjectID string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.keys, subjectID)
+	s.forgotten[subjectID] = struct{}{}
+
+	return nil
+}
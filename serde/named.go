@@ -0,0 +1,31 @@
+package serde
+
+// NamedDeserializer is used to deserialize a Source type from another Destination type,
+// knowing in advance the name of the concrete type to deserialize.
+//
+// Storage implementations that persist the type name alongside the serialized data
+// (e.g. the "type" column of the postgres.EventStore events table) use this interface,
+// when implemented, instead of Deserializer, which allows to serialize plain payloads
+// that do not carry any type information.
+type NamedDeserializer[Src any, Dst any] interface {
+	DeserializeNamed(name string, dst Dst) (Src, error)
+}
+
+// NamedDeserializerFunc is a functional implementation of the NamedDeserializer interface.
+type NamedDeserializerFunc[Src any, Dst any] func(name string, dst Dst) (Src, error)
+
+// DeserializeNamed implements the serde.NamedDeserializer interface.
+func (fn NamedDeserializerFunc[Src, Dst]) DeserializeNamed(name string, dst Dst) (Src, error) {
+	return fn(name, dst)
+}
+
+// DeserializeNamed deserializes the provided data using the specified type name,
+// if the Deserializer also implements the NamedDeserializer interface,
+// or falls back to Deserializer.Deserialize otherwise.
+func DeserializeNamed[Src, Dst any](deserializer Deserializer[Src, Dst], name string, dst Dst) (Src, error) {
+	if named, ok := deserializer.(NamedDeserializer[Src, Dst]); ok {
+		return named.DeserializeNamed(name, dst)
+	}
+
+	return deserializer.Deserialize(dst)
+}
@@ -0,0 +1,202 @@
+package serde
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/get-eventually/go-eventually/message"
+)
+
+//nolint:exhaustruct // Interface implementation assertions.
+var (
+	_ Bytes[message.Message]                     = new(Registry)
+	_ Bytes[message.Message]                     = PlainRegistry{}
+	_ NamedDeserializer[message.Message, []byte] = PlainRegistry{}
+)
+
+var (
+	// ErrUnknownMessageType is returned by Registry when serializing or deserializing
+	// a message.Message whose type name has not been registered.
+	ErrUnknownMessageType = errors.New("serde.Registry: unknown message type")
+
+	// ErrMessageTypeAlreadyRegistered is returned by Register when a serde has already
+	// been registered for the same type name.
+	ErrMessageTypeAlreadyRegistered = errors.New("serde.Registry: message type already registered")
+
+	// ErrUnexpectedMessageType is returned by Registry when serializing a message.Message
+	// whose Go type does not match the one registered for its type name.
+	ErrUnexpectedMessageType = errors.New("serde.Registry: unexpected message type")
+
+	// ErrMessageTypeNameRequired is returned by PlainRegistry when deserializing
+	// a plain payload without knowing its type name.
+	ErrMessageTypeNameRequired = errors.New("serde.PlainRegistry: message type name required")
+)
+
+// registryEnvelope is the self-describing wire format produced by Registry.
+type registryEnvelope struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+// Registry is a serde.Bytes[message.Message] implementation that routes
+// the serialization and deserialization of each message.Message to the serde
+// registered for its type name, as returned by message.Message.Name().
+//
+// Serialized messages are wrapped in a self-describing JSON envelope,
+// carrying the type name and the payload produced by the registered serde.
+//
+// Use Register to add new message types to the Registry, and Plain
+// to serialize plain payloads with no envelope instead.
+type Registry struct {
+	mx     sync.RWMutex
+	serdes map[string]Bytes[message.Message]
+}
+
+// NewRegistry returns a new, empty Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		mx:     sync.RWMutex{},
+		serdes: make(map[string]Bytes[message.Message]),
+	}
+}
+
+// Register registers the serde to use for the message type T,
+// identified by the specified type name.
+//
+// The type name must match the value returned by message.Message.Name()
+// for the registered type.
+func Register[T message.Message](r *Registry, name string, serde Bytes[T]) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.serdes[name]; ok {
+		return fmt.Errorf("serde.Register: failed to register '%s', %w", name, ErrMessageTypeAlreadyRegistered)
+	}
+
+	r.serdes[name] = Fuse[message.Message, []byte](
+		SerializerFunc[message.Message, []byte](func(msg message.Message) ([]byte, error) {
+			t, ok := msg.(T)
+			if !ok {
+				return nil, fmt.Errorf("%w, expected %T for '%s', got %T", ErrUnexpectedMessageType, t, name, msg)
+			}
+
+			return serde.Serialize(t)
+		}),
+		DeserializerFunc[message.Message, []byte](func(data []byte) (message.Message, error) {
+			return serde.Deserialize(data)
+		}),
+	)
+
+	return nil
+}
+
+func (r *Registry) serdeFor(name string) (Bytes[message.Message], error) {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	s, ok := r.serdes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownMessageType, name)
+	}
+
+	return s, nil
+}
+
+// Serialize implements the serde.Serializer interface.
+func (r *Registry) Serialize(msg message.Message) ([]byte, error) {
+	name := msg.Name()
+
+	s, err := r.serdeFor(name)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to serialize message, %w", err)
+	}
+
+	data, err := s.Serialize(msg)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to serialize '%s', %w", name, err)
+	}
+
+	envelope, err := json.Marshal(registryEnvelope{Type: name, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to serialize envelope, %w", err)
+	}
+
+	return envelope, nil
+}
+
+// Deserialize implements the serde.Deserializer interface.
+func (r *Registry) Deserialize(data []byte) (message.Message, error) {
+	var envelope registryEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to deserialize envelope, %w", err)
+	}
+
+	s, err := r.serdeFor(envelope.Type)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to deserialize message, %w", err)
+	}
+
+	msg, err := s.Deserialize(envelope.Data)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Registry: failed to deserialize '%s', %w", envelope.Type, err)
+	}
+
+	return msg, nil
+}
+
+// Plain returns a PlainRegistry using the serdes registered in the Registry.
+func (r *Registry) Plain() PlainRegistry {
+	return PlainRegistry{registry: r}
+}
+
+// PlainRegistry is a serde.Bytes[message.Message] implementation that serializes
+// each message.Message as the plain payload produced by the serde registered
+// for its type name, with no self-describing envelope.
+//
+// Since plain payloads carry no type information, they can only be deserialized
+// through DeserializeNamed, e.g. by storage implementations that persist the type name
+// alongside the payload, like postgres.EventStore.
+type PlainRegistry struct {
+	registry *Registry
+}
+
+// Serialize implements the serde.Serializer interface.
+func (r PlainRegistry) Serialize(msg message.Message) ([]byte, error) {
+	name := msg.Name()
+
+	s, err := r.registry.serdeFor(name)
+	if err != nil {
+		return nil, fmt.Errorf("serde.PlainRegistry: failed to serialize message, %w", err)
+	}
+
+	data, err := s.Serialize(msg)
+	if err != nil {
+		return nil, fmt.Errorf("serde.PlainRegistry: failed to serialize '%s', %w", name, err)
+	}
+
+	return data, nil
+}
+
+// Deserialize implements the serde.Deserializer interface.
+//
+// It always returns ErrMessageTypeNameRequired: use DeserializeNamed instead.
+func (r PlainRegistry) Deserialize([]byte) (message.Message, error) {
+	return nil, ErrMessageTypeNameRequired
+}
+
+// DeserializeNamed implements the serde.NamedDeserializer interface.
+func (r PlainRegistry) DeserializeNamed(name string, data []byte) (message.Message, error) {
+	s, err := r.registry.serdeFor(name)
+	if err != nil {
+		return nil, fmt.Errorf("serde.PlainRegistry: failed to deserialize message, %w", err)
+	}
+
+	msg, err := s.Deserialize(data)
+	if err != nil {
+		return nil, fmt.Errorf("serde.PlainRegistry: failed to deserialize '%s', %w", name, err)
+	}
+
+	return msg, nil
+}
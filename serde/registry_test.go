@@ -0,0 +1,133 @@
+package serde_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/message"
+	"github.com/get-eventually/go-eventually/serde"
+)
+
+type itemWasAdded struct {
+	ID string `json:"id"`
+}
+
+func (*itemWasAdded) Name() string { return "ItemWasAdded" }
+
+type itemWasRemoved struct {
+	ID string `json:"id"`
+}
+
+func (*itemWasRemoved) Name() string { return "ItemWasRemoved" }
+
+type impostor struct{}
+
+func (impostor) Name() string { return "ItemWasAdded" }
+
+func TestRegistry(t *testing.T) {
+	registry := serde.NewRegistry()
+
+	require.NoError(t, serde.Register(registry, "ItemWasAdded",
+		serde.NewJSON(func() *itemWasAdded { return new(itemWasAdded) })))
+	require.NoError(t, serde.Register(registry, "ItemWasRemoved",
+		serde.NewJSON(func() *itemWasRemoved { return new(itemWasRemoved) })))
+
+	t.Run("register fails when the type is already registered", func(t *testing.T) {
+		err := serde.Register(registry, "ItemWasAdded",
+			serde.NewJSON(func() *itemWasAdded { return new(itemWasAdded) }))
+		assert.ErrorIs(t, err, serde.ErrMessageTypeAlreadyRegistered)
+	})
+
+	t.Run("messages are routed to their registered serde", func(t *testing.T) {
+		for _, msg := range []message.Message{
+			&itemWasAdded{ID: "item-1"},
+			&itemWasRemoved{ID: "item-1"},
+		} {
+			data, err := registry.Serialize(msg)
+			require.NoError(t, err)
+
+			deserialized, err := registry.Deserialize(data)
+			require.NoError(t, err)
+			assert.Equal(t, msg, deserialized)
+		}
+	})
+
+	t.Run("serialize fails on unknown message types", func(t *testing.T) {
+		_, err := serde.NewRegistry().Serialize(&itemWasAdded{ID: "item-1"})
+		assert.ErrorIs(t, err, serde.ErrUnknownMessageType)
+	})
+
+	t.Run("serialize fails on unexpected go types", func(t *testing.T) {
+		_, err := registry.Serialize(impostor{})
+		assert.ErrorIs(t, err, serde.ErrUnexpectedMessageType)
+	})
+
+	t.Run("deserialize fails on unknown message types", func(t *testing.T) {
+		data, err := registry.Serialize(&itemWasAdded{ID: "item-1"})
+		require.NoError(t, err)
+
+		_, err = serde.NewRegistry().Deserialize(data)
+		assert.ErrorIs(t, err, serde.ErrUnknownMessageType)
+	})
+
+	t.Run("deserialize fails on malformed envelopes", func(t *testing.T) {
+		_, err := registry.Deserialize([]byte("not-an-envelope"))
+		assert.Error(t, err)
+	})
+
+	t.Run("plain payloads are deserialized by type name", func(t *testing.T) {
+		plain := registry.Plain()
+		msg := &itemWasAdded{ID: "item-1"}
+
+		data, err := plain.Serialize(msg)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"item-1"}`, string(data))
+
+		deserialized, err := serde.DeserializeNamed[message.Message](plain, msg.Name(), data)
+		require.NoError(t, err)
+		assert.Equal(t, msg, deserialized)
+
+		_, err = plain.Deserialize(data)
+		assert.ErrorIs(t, err, serde.ErrMessageTypeNameRequired)
+
+		_, err = plain.DeserializeNamed("Unknown", data)
+		assert.ErrorIs(t, err, serde.ErrUnknownMessageType)
+	})
+
+	t.Run("plain payloads are deserialized by type name through wrapping serdes", func(t *testing.T) {
+		msg := &itemWasAdded{ID: "item-1"}
+
+		for name, wrapped := range map[string]serde.Bytes[message.Message]{
+			"chained": serde.Compress[message.Message](registry.Plain(), serde.CodecGzip, 0),
+			"shredded": serde.NewShredded[message.Message](
+				registry.Plain(),
+				serde.NewShredder(serde.NewInMemoryKeyStore()),
+				func(message.Message) string { return "subject-1" },
+				func(string) message.Message { return nil },
+			),
+		} {
+			t.Run(name, func(t *testing.T) {
+				data, err := wrapped.Serialize(msg)
+				require.NoError(t, err)
+
+				deserialized, err := serde.DeserializeNamed(wrapped, msg.Name(), data)
+				require.NoError(t, err)
+				assert.Equal(t, msg, deserialized)
+
+				_, err = wrapped.Deserialize(data)
+				assert.ErrorIs(t, err, serde.ErrMessageTypeNameRequired)
+			})
+		}
+	})
+
+	t.Run("deserialize named falls back to the type-unaware deserializer", func(t *testing.T) {
+		data, err := registry.Serialize(&itemWasRemoved{ID: "item-1"})
+		require.NoError(t, err)
+
+		deserialized, err := serde.DeserializeNamed[message.Message](registry, "Ignored", data)
+		require.NoError(t, err)
+		assert.Equal(t, &itemWasRemoved{ID: "item-1"}, deserialized)
+	})
+}
@@ -0,0 +1,245 @@
+package serde
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ForgottenPlaceholder is the value returned by Shredder.DecryptString
+// in place of the personal data of a forgotten data subject.
+const ForgottenPlaceholder = "<forgotten>"
+
+var (
+	// ErrSubjectForgotten is returned by Shredder.Decrypt when the encryption key
+	// of the data subject has been deleted from the KeyStore, and by KeyStore.CreateKey
+	// (hence Shredder.Encrypt) to prevent new personal data of the data subject from being stored.
+	ErrSubjectForgotten = errors.New("serde.Shredder: data subject has been forgotten")
+
+	// ErrInvalidCiphertext is returned by Shredder when decrypting data
+	// that has not been produced by Shredder.Encrypt.
+	ErrInvalidCiphertext = errors.New("serde.Shredder: invalid ciphertext")
+)
+
+// Shredder encrypts and decrypts personal data using AES-GCM,
+// with the per-subject encryption keys found in a KeyStore.
+//
+// Once the key of a data subject is deleted from the KeyStore, all its
+// personal data can no longer be decrypted: this allows to honor erasure
+// requests while keeping the Event Streams immutable.
+//
+// Use EncryptString and DecryptString in custom serdes to encrypt designated fields,
+// or Shredded to encrypt whole payloads.
+type Shredder struct {
+	keys KeyStore
+}
+
+// NewShredder returns a new Shredder instance using the provided KeyStore.
+func NewShredder(keys KeyStore) Shredder {
+	return Shredder{keys: keys}
+}
+
+// Encrypt encrypts the provided data with the key of the specified data subject,
+// creating a new key if it does not exist.
+//
+// ErrSubjectForgotten is returned if the key of the data subject has been deleted.
+func (s Shredder) Encrypt(ctx context.Context, subjectID string, data []byte) ([]byte, error) {
+	key, err := s.keys.CreateKey(ctx, subjectID)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredder: failed to get encryption key, %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("serde.Shredder: failed to generate nonce, %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, data, []byte(subjectID)), nil
+}
+
+// Decrypt decrypts the provided data with the key of the specified data subject.
+//
+// ErrSubjectForgotten is returned if the key of the data subject has been deleted.
+func (s Shredder) Decrypt(ctx context.Context, subjectID string, data []byte) ([]byte, error) {
+	key, err := s.keys.Key(ctx, subjectID)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrSubjectForgotten
+	} else if err != nil {
+		return nil, fmt.Errorf("serde.Shredder: failed to get encryption key, %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(subjectID))
+	if err != nil {
+		return nil, fmt.Errorf("%w, %w", ErrInvalidCiphertext, err)
+	}
+
+	return plaintext, nil
+}
+
+// EncryptString encrypts the provided personal data field with the key
+// of the specified data subject, returning it encoded in base64.
+func (s Shredder) EncryptString(ctx context.Context, subjectID, value string) (string, error) {
+	data, err := s.Encrypt(ctx, subjectID, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DecryptString decrypts a personal data field previously encrypted with EncryptString.
+//
+// ForgottenPlaceholder is returned if the key of the data subject has been deleted.
+func (s Shredder) DecryptString(ctx context.Context, subjectID, value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("%w, %w", ErrInvalidCiphertext, err)
+	}
+
+	plaintext, err := s.Decrypt(ctx, subjectID, data)
+	if errors.Is(err, ErrSubjectForgotten) {
+		return ForgottenPlaceholder, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredder: failed to create cipher, %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredder: failed to create cipher, %w", err)
+	}
+
+	return aead, nil
+}
+
+// shreddedEnvelope is the wire format produced by Shredded.
+type shreddedEnvelope struct {
+	SubjectID string `json:"subject_id"`
+	Data      []byte `json:"data"`
+}
+
+//nolint:exhaustruct // Interface implementation assertion.
+var _ NamedDeserializer[any, []byte] = Shredded[any]{}
+
+// Shredded is a serde.Bytes implementation that encrypts the whole payload
+// produced by another serde with the key of the data subject it belongs to,
+// using a Shredder.
+//
+// Once the data subject has been forgotten, Deserialize returns the placeholder
+// value built by the provided forgotten function, instead of failing.
+//
+// Since serdes are not context-aware, the KeyStore is accessed
+// using context.Background().
+type Shredded[T any] struct {
+	serde     Bytes[T]
+	shredder  Shredder
+	subject   func(T) string
+	forgotten func(subjectID string) T
+}
+
+// NewShredded returns a new Shredded serde instance, encrypting the payloads
+// produced by the provided serde.
+//
+// The subject function returns the identifier of the data subject a value belongs to,
+// while the forgotten function builds the placeholder value returned
+// on deserialization once the data subject has been forgotten.
+func NewShredded[T any](
+	serde Bytes[T],
+	shredder Shredder,
+	subject func(T) string,
+	forgotten func(subjectID string) T,
+) Shredded[T] {
+	return Shredded[T]{
+		serde:     serde,
+		shredder:  shredder,
+		subject:   subject,
+		forgotten: forgotten,
+	}
+}
+
+// Serialize implements the serde.Serializer interface.
+func (s Shredded[T]) Serialize(t T) ([]byte, error) {
+	data, err := s.serde.Serialize(t)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredded: failed to serialize data, %w", err)
+	}
+
+	subjectID := s.subject(t)
+
+	ciphertext, err := s.shredder.Encrypt(context.Background(), subjectID, data)
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredded: failed to encrypt data, %w", err)
+	}
+
+	envelope, err := json.Marshal(shreddedEnvelope{SubjectID: subjectID, Data: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("serde.Shredded: failed to serialize envelope, %w", err)
+	}
+
+	return envelope, nil
+}
+
+// Deserialize implements the serde.Deserializer interface.
+func (s Shredded[T]) Deserialize(data []byte) (T, error) {
+	return s.deserialize(data, s.serde.Deserialize)
+}
+
+// DeserializeNamed implements the serde.NamedDeserializer interface,
+// forwarding the type name to the wrapped serde, if it implements it as well.
+func (s Shredded[T]) DeserializeNamed(name string, data []byte) (T, error) {
+	return s.deserialize(data, func(plaintext []byte) (T, error) {
+		return DeserializeNamed(s.serde, name, plaintext)
+	})
+}
+
+func (s Shredded[T]) deserialize(data []byte, deserialize func([]byte) (T, error)) (T, error) {
+	var zeroValue T
+
+	var envelope shreddedEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return zeroValue, fmt.Errorf("serde.Shredded: failed to deserialize envelope, %w", err)
+	}
+
+	plaintext, err := s.shredder.Decrypt(context.Background(), envelope.SubjectID, envelope.Data)
+	if errors.Is(err, ErrSubjectForgotten) {
+		return s.forgotten(envelope.SubjectID), nil
+	} else if err != nil {
+		return zeroValue, fmt.Errorf("serde.Shredded: failed to decrypt data, %w", err)
+	}
+
+	t, err := deserialize(plaintext)
+	if err != nil {
+		return zeroValue, fmt.Errorf("serde.Shredded: failed to deserialize data, %w", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,98 @@
+package serde_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/serde"
+)
+
+type personalData struct {
+	SubjectID string `json:"subject_id"`
+	Email     string `json:"email"`
+}
+
+func TestShredder(t *testing.T) {
+	ctx := t.Context()
+	keys := serde.NewInMemoryKeyStore()
+	shredder := serde.NewShredder(keys)
+
+	t.Run("encrypted fields can be decrypted until the subject is forgotten", func(t *testing.T) {
+		encrypted, err := shredder.EncryptString(ctx, "subject-1", "john@doe.com")
+		require.NoError(t, err)
+		assert.NotEqual(t, "john@doe.com", encrypted)
+
+		decrypted, err := shredder.DecryptString(ctx, "subject-1", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "john@doe.com", decrypted)
+
+		require.NoError(t, keys.DeleteKey(ctx, "subject-1"))
+
+		decrypted, err = shredder.DecryptString(ctx, "subject-1", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, serde.ForgottenPlaceholder, decrypted)
+
+		_, err = shredder.Decrypt(ctx, "subject-1", []byte(encrypted))
+		assert.ErrorIs(t, err, serde.ErrSubjectForgotten)
+	})
+
+	t.Run("no new personal data can be encrypted once the subject is forgotten", func(t *testing.T) {
+		_, err := shredder.EncryptString(ctx, "subject-4", "john@doe.com")
+		require.NoError(t, err)
+
+		require.NoError(t, keys.DeleteKey(ctx, "subject-4"))
+
+		_, err = shredder.EncryptString(ctx, "subject-4", "john.doe@mail.com")
+		require.ErrorIs(t, err, serde.ErrSubjectForgotten)
+
+		_, err = keys.CreateKey(ctx, "subject-4")
+		require.ErrorIs(t, err, serde.ErrSubjectForgotten)
+
+		require.NoError(t, keys.DeleteKey(ctx, "subject-5"))
+
+		_, err = keys.CreateKey(ctx, "subject-5")
+		require.ErrorIs(t, err, serde.ErrSubjectForgotten)
+	})
+
+	t.Run("data encrypted for a subject cannot be decrypted as another one", func(t *testing.T) {
+		encrypted, err := shredder.Encrypt(ctx, "subject-2", []byte("secret"))
+		require.NoError(t, err)
+
+		_, err = keys.CreateKey(ctx, "subject-3")
+		require.NoError(t, err)
+
+		_, err = shredder.Decrypt(ctx, "subject-3", encrypted)
+		assert.ErrorIs(t, err, serde.ErrInvalidCiphertext)
+	})
+}
+
+func TestShredded(t *testing.T) {
+	keys := serde.NewInMemoryKeyStore()
+
+	shredded := serde.NewShredded(
+		serde.NewJSON(func() *personalData { return new(personalData) }),
+		serde.NewShredder(keys),
+		func(data *personalData) string { return data.SubjectID },
+		func(subjectID string) *personalData {
+			return &personalData{SubjectID: subjectID, Email: serde.ForgottenPlaceholder}
+		},
+	)
+
+	data := &personalData{SubjectID: "subject-1", Email: "john@doe.com"}
+
+	serialized, err := shredded.Serialize(data)
+	require.NoError(t, err)
+	assert.NotContains(t, string(serialized), "john@doe.com")
+
+	deserialized, err := shredded.Deserialize(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, data, deserialized)
+
+	require.NoError(t, keys.DeleteKey(t.Context(), "subject-1"))
+
+	deserialized, err = shredded.Deserialize(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, &personalData{SubjectID: "subject-1", Email: serde.ForgottenPlaceholder}, deserialized)
+}
@@ -0,0 +1,141 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/get-eventually/go-eventually/event"
+)
+
+// DefaultPollInterval is the default interval used by a CatchUp subscription
+// to poll the Event Store for new Domain Events, once it has caught up with the log.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Option can be used to change the configuration of a CatchUp subscription.
+type Option interface {
+	apply(*CatchUp)
+}
+
+type option func(*CatchUp)
+
+func (apply option) apply(s *CatchUp) { apply(s) }
+
+// WithPollInterval specifies the interval used by the CatchUp subscription
+// to poll the Event Store for new Domain Events, once caught up.
+func WithPollInterval(interval time.Duration) Option {
+	return option(func(s *CatchUp) {
+		s.pollInterval = interval
+	})
+}
+
+// CatchUp is a subscription that feeds an event.Processor with the Domain Events
+// from the global Event Store log.
+//
+// On start, a CatchUp subscription replays all the Domain Events committed
+// after the position stored in its Checkpointer, then switches to live tailing
+// by polling the Event Store for newly committed Domain Events.
+//
+// Domain Events are processed in order, with at-least-once semantics:
+// the checkpoint is written only after a Domain Event has been successfully
+// processed, so the event.Processor might receive the same Domain Event again
+// in case of failures between processing and checkpointing.
+type CatchUp struct {
+	name         string
+	eventStore   event.AllStreamer
+	checkpointer Checkpointer
+	processor    event.Processor
+	pollInterval time.Duration
+}
+
+// NewCatchUp returns a new CatchUp subscription, identified by the provided name,
+// that feeds the event.Processor with the Domain Events coming from the Event Store.
+//
+// The subscription name is used to read and write checkpoints
+// through the provided Checkpointer.
+func NewCatchUp(
+	name string,
+	eventStore event.AllStreamer,
+	checkpointer Checkpointer,
+	processor event.Processor,
+	options ...Option,
+) *CatchUp {
+	s := &CatchUp{
+		name:         name,
+		eventStore:   eventStore,
+		checkpointer: checkpointer,
+		processor:    processor,
+		pollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range options {
+		opt.apply(s)
+	}
+
+	return s
+}
+
+// Name returns the name of the subscription.
+func (s *CatchUp) Name() string { return s.name }
+
+// Run starts the subscription, blocking until the context is canceled
+// or processing fails.
+//
+// When the context is canceled, Run returns the context error.
+// Run also returns an error if the event.Processor fails to process a Domain Event,
+// or if either the Event Store or the Checkpointer fail.
+func (s *CatchUp) Run(ctx context.Context) error {
+	position, err := s.checkpointer.Read(ctx, s.name)
+	if err != nil {
+		return fmt.Errorf("subscription.CatchUp: failed to read checkpoint for %q, %w", s.name, err)
+	}
+
+	for {
+		if position, err = s.CatchUpFrom(ctx, position); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("subscription.CatchUp: subscription %q stopped, %w", s.name, ctx.Err())
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// CatchUpFrom processes all the Domain Events committed after the provided position,
+// writing their checkpoints, and returns the position of the last Domain Event processed.
+//
+// Run calls CatchUpFrom on every poll: use it directly to drive the subscription
+// with a custom polling loop, like projection.Runner does.
+func (s *CatchUp) CatchUpFrom(ctx context.Context, position event.Position) (event.Position, error) {
+	var processErr error
+
+	stream := s.eventStore.StreamAll(ctx, event.PositionSelector{From: position + 1})
+
+	for evt := range stream.Iter() {
+		if processErr = s.processor.Process(ctx, evt); processErr != nil {
+			processErr = fmt.Errorf("subscription.CatchUp: failed to process event at position %d, %w", evt.Position, processErr)
+
+			break
+		}
+
+		if processErr = s.checkpointer.Write(ctx, s.name, evt.Position); processErr != nil {
+			processErr = fmt.Errorf("subscription.CatchUp: failed to write checkpoint for %q, %w", s.name, processErr)
+
+			break
+		}
+
+		position = evt.Position
+	}
+
+	if processErr != nil {
+		return position, processErr
+	}
+
+	if err := stream.Err(); err != nil {
+		return position, fmt.Errorf("subscription.CatchUp: failed to stream events, %w", err)
+	}
+
+	return position, nil
+}
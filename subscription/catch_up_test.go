@@ -0,0 +1,124 @@
+package subscription_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/subscription"
+	"github.com/get-eventually/go-eventually/version"
+)
+
+type noopMessage struct{ id int }
+
+func (noopMessage) Name() string { return "noop" }
+
+const (
+	testStreamID         event.StreamID = "stream"
+	testSubscriptionName                = "test-subscription"
+	testPollInterval                    = 5 * time.Millisecond
+)
+
+type collectingProcessor struct {
+	mx  sync.Mutex
+	ids []int
+}
+
+func (p *collectingProcessor) Process(_ context.Context, evt event.Persisted) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.ids = append(p.ids, evt.Message.(noopMessage).id) //nolint:errcheck,forcetypeassert // test helper
+
+	return nil
+}
+
+func (p *collectingProcessor) collected() []int {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return append([]int(nil), p.ids...)
+}
+
+func appendIDs(t *testing.T, store event.Appender, ids ...int) {
+	t.Helper()
+
+	for _, id := range ids {
+		_, err := store.Append(t.Context(), testStreamID, version.Any, event.ToEnvelope(noopMessage{id: id}))
+		require.NoError(t, err)
+	}
+}
+
+func TestCatchUp_ReplaysFromCheckpointThenTailsLiveEvents(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3, 4)
+
+	checkpointer := subscription.NewInMemoryCheckpointer()
+	require.NoError(t, checkpointer.Write(t.Context(), testSubscriptionName, 2))
+
+	processor := new(collectingProcessor)
+	sub := subscription.NewCatchUp(
+		testSubscriptionName, store, checkpointer, processor,
+		subscription.WithPollInterval(testPollInterval),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errs := make(chan error, 1)
+
+	go func() { errs <- sub.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return len(processor.collected()) == 2
+	}, time.Second, testPollInterval)
+
+	appendIDs(t, store, 5, 6)
+
+	require.Eventually(t, func() bool {
+		return len(processor.collected()) == 4
+	}, time.Second, testPollInterval)
+
+	cancel()
+
+	require.ErrorIs(t, <-errs, context.Canceled)
+	assert.Equal(t, []int{3, 4, 5, 6}, processor.collected())
+
+	position, err := checkpointer.Read(t.Context(), testSubscriptionName)
+	require.NoError(t, err)
+	assert.Equal(t, event.Position(6), position)
+}
+
+func TestCatchUp_StopsOnProcessorError(t *testing.T) {
+	store := event.NewInMemoryStore()
+	appendIDs(t, store, 1, 2, 3)
+
+	wantErr := errors.New("processor failed")
+	checkpointer := subscription.NewInMemoryCheckpointer()
+
+	processor := event.ProcessorFunc(func(_ context.Context, evt event.Persisted) error {
+		if evt.Position == 2 {
+			return wantErr
+		}
+
+		return nil
+	})
+
+	sub := subscription.NewCatchUp(
+		testSubscriptionName, store, checkpointer, processor,
+		subscription.WithPollInterval(testPollInterval),
+	)
+
+	err := sub.Run(t.Context())
+	require.ErrorIs(t, err, wantErr)
+
+	position, err := checkpointer.Read(t.Context(), testSubscriptionName)
+	require.NoError(t, err)
+	assert.Equal(t, event.Position(1), position, "checkpoint should not advance past the failed event")
+}
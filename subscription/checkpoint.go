@@ -0,0 +1,58 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+
+	"github.com/get-eventually/go-eventually/event"
+)
+
+// Checkpointer is used by subscriptions to durably keep track of
+// the position of the last Domain Event processed in the global Event Store log,
+// addressed by the subscription name.
+type Checkpointer interface {
+	// Read returns the position of the last Domain Event processed by the named
+	// subscription.
+	//
+	// A zero value is returned if no checkpoint has been written yet.
+	Read(ctx context.Context, name string) (event.Position, error)
+
+	// Write stores the position of the last Domain Event processed
+	// by the named subscription.
+	Write(ctx context.Context, name string, position event.Position) error
+}
+
+// Interface implementation assertion.
+var _ Checkpointer = new(InMemoryCheckpointer)
+
+// InMemoryCheckpointer is a thread-safe, in-memory Checkpointer implementation.
+type InMemoryCheckpointer struct {
+	mx          sync.RWMutex
+	checkpoints map[string]event.Position
+}
+
+// NewInMemoryCheckpointer creates a new subscription.InMemoryCheckpointer instance.
+func NewInMemoryCheckpointer() *InMemoryCheckpointer {
+	return &InMemoryCheckpointer{
+		mx:          sync.RWMutex{},
+		checkpoints: make(map[string]event.Position),
+	}
+}
+
+// Read implements the subscription.Checkpointer interface.
+func (c *InMemoryCheckpointer) Read(_ context.Context, name string) (event.Position, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	return c.checkpoints[name], nil
+}
+
+// Write implements the subscription.Checkpointer interface.
+func (c *InMemoryCheckpointer) Write(_ context.Context, name string, position event.Position) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.checkpoints[name] = position
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package subscription_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/get-eventually/go-eventually/event"
+	"github.com/get-eventually/go-eventually/subscription"
+)
+
+func TestInMemoryCheckpointer(t *testing.T) {
+	checkpointer := subscription.NewInMemoryCheckpointer()
+
+	position, err := checkpointer.Read(t.Context(), testSubscriptionName)
+	require.NoError(t, err)
+	assert.Zero(t, position, "missing checkpoints should return the zero position")
+
+	require.NoError(t, checkpointer.Write(t.Context(), testSubscriptionName, 42))
+	require.NoError(t, checkpointer.Write(t.Context(), "other", 1))
+
+	position, err = checkpointer.Read(t.Context(), testSubscriptionName)
+	require.NoError(t, err)
+	assert.Equal(t, event.Position(42), position)
+}
@@ -0,0 +1,3 @@
+// Package subscription contains components to drive event.Processor instances
+// using the global Event Store log, such as catch-up subscriptions.
+package subscription
@@ -1,27 +1,104 @@
 package version
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // Any avoids optimistic concurrency checks when requiring a version.Check instance.
 var Any = CheckAny{}
 
+// NoStream ensures the Event Stream does not exist yet when appending,
+// e.g. when creating a new Aggregate Root.
+var NoStream = CheckNoStream{}
+
+// StreamExists ensures the Event Stream already exists when appending,
+// regardless of its current version.
+var StreamExists = CheckStreamExists{}
+
+var (
+	// ErrStreamAlreadyExists is returned by an Event Store when appending
+	// with the NoStream check to an Event Stream that already exists.
+	ErrStreamAlreadyExists = errors.New("version.Check: event stream already exists")
+
+	// ErrStreamNotFound is returned by an Event Store when appending
+	// with the StreamExists check to an Event Stream that does not exist.
+	ErrStreamNotFound = errors.New("version.Check: event stream does not exist")
+)
+
 // Check can be used to perform optimistic concurrency checks when writing to
 // the Event Store using the event.Appender interface.
 type Check interface {
+	// Verify verifies the Check against the current version of the Event Stream,
+	// where a zero version means the Event Stream does not exist.
+	//
+	// Useful for Event Store implementations.
+	Verify(current Version) error
+
 	isVersionCheck()
 }
 
 // CheckAny is a Check variant that will avoid optimistic concurrency checks when used.
 type CheckAny struct{}
 
+// Verify implements the version.Check interface.
+func (CheckAny) Verify(Version) error { return nil }
+
 func (CheckAny) isVersionCheck() {}
 
 // CheckExact is a Check variant that will ensure the specified version is the current one
 // (typically used when needing to check the version of an Event Stream).
 type CheckExact Version
 
+// Verify implements the version.Check interface.
+//
+// A ConflictError is returned if the current version is not the expected one.
+func (check CheckExact) Verify(current Version) error {
+	if current != Version(check) {
+		return ConflictError{
+			Expected: Version(check),
+			Actual:   current,
+		}
+	}
+
+	return nil
+}
+
 func (CheckExact) isVersionCheck() {}
 
+// CheckNoStream is a Check variant that will ensure the Event Stream does not exist yet.
+//
+// Event Stores return ErrStreamAlreadyExists when the check fails.
+type CheckNoStream struct{}
+
+// Verify implements the version.Check interface.
+func (CheckNoStream) Verify(current Version) error {
+	if current != 0 {
+		return ErrStreamAlreadyExists
+	}
+
+	return nil
+}
+
+func (CheckNoStream) isVersionCheck() {}
+
+// CheckStreamExists is a Check variant that will ensure the Event Stream exists,
+// without checking its current version.
+//
+// Event Stores return ErrStreamNotFound when the check fails.
+type CheckStreamExists struct{}
+
+// Verify implements the version.Check interface.
+func (CheckStreamExists) Verify(current Version) error {
+	if current == 0 {
+		return ErrStreamNotFound
+	}
+
+	return nil
+}
+
+func (CheckStreamExists) isVersionCheck() {}
+
 // ConflictError is an error returned by an Event Store when appending
 // some events using an expected Event Stream version that does not match
 // the current state of the Event Stream.
@@ -0,0 +1,38 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/get-eventually/go-eventually/version"
+)
+
+func TestCheck_Verify(t *testing.T) {
+	testCases := []struct {
+		name    string
+		check   version.Check
+		current version.Version
+		want    error
+	}{
+		{name: "any passes on missing stream", check: version.Any, current: 0, want: nil},
+		{name: "any passes on existing stream", check: version.Any, current: 3, want: nil},
+		{name: "exact passes on the expected version", check: version.CheckExact(3), current: 3, want: nil},
+		{
+			name:    "exact fails on a different version",
+			check:   version.CheckExact(2),
+			current: 3,
+			want:    version.ConflictError{Expected: 2, Actual: 3},
+		},
+		{name: "no stream passes on missing stream", check: version.NoStream, current: 0, want: nil},
+		{name: "no stream fails on existing stream", check: version.NoStream, current: 1, want: version.ErrStreamAlreadyExists},
+		{name: "stream exists passes on existing stream", check: version.StreamExists, current: 1, want: nil},
+		{name: "stream exists fails on missing stream", check: version.StreamExists, current: 0, want: version.ErrStreamNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.check.Verify(tc.current))
+		})
+	}
+}
@@ -5,10 +5,43 @@ package version
 type Version uint32
 
 // SelectFromBeginning is a Selector value that will return all Domain Events in an Event Stream.
-var SelectFromBeginning = Selector{From: 0}
+var SelectFromBeginning = Selector{From: 0, To: 0, Direction: Forward}
+
+// Direction specifies the order in which Domain Events are streamed
+// from an Event Stream.
+type Direction uint8
+
+const (
+	// Forward streams Domain Events from the oldest to the newest version.
+	Forward Direction = iota
+	// Backward streams Domain Events from the newest to the oldest version.
+	Backward
+)
+
+func (d Direction) String() string {
+	if d == Backward {
+		return "backward"
+	}
+
+	return "forward"
+}
 
 // Selector specifies which slice of the Event Stream to select when streaming Domain Events
 // from the Event Store.
+//
+// Both From and To bounds are inclusive. A zero To means no upper bound,
+// i.e. up to the latest version of the Event Stream.
+//
+// Use Direction to stream the selected slice backwards, e.g. to read
+// the latest Domain Events of an Event Stream first.
 type Selector struct {
-	From Version
+	From      Version
+	To        Version
+	Direction Direction
+}
+
+// Includes returns true if the specified version falls within
+// the bounds of the Selector.
+func (s Selector) Includes(v Version) bool {
+	return v >= s.From && (s.To == 0 || v <= s.To)
 }